@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -23,6 +24,12 @@ type Config struct {
 	CORSAllowedOrigins     string
 	CORSAllowedMethods     string
 	APIHost                string
+	JobWorkers             int
+	JobRetention           time.Duration
+	SlaCheckInterval       time.Duration
+	BusinessTimezone       string
+	WorkingDays            string
+	WorkingHours           string
 }
 
 func LoadConfig() *Config {
@@ -33,6 +40,15 @@ func LoadConfig() *Config {
 
 	jwtExpireHours, _ := strconv.Atoi(getEnv("JWT_EXPIRE_HOURS", "24"))
 	refreshTokenExpireDays, _ := strconv.Atoi(getEnv("REFRESH_TOKEN_EXPIRE_DAYS", "28"))
+	jobWorkers, _ := strconv.Atoi(getEnv("JOB_WORKERS", "2"))
+	jobRetentionDays, _ := strconv.Atoi(getEnv("JOB_RETENTION_DAYS", "30"))
+	if jobRetentionDays < 1 {
+		jobRetentionDays = 30
+	}
+	slaCheckMinutes, _ := strconv.Atoi(getEnv("SLA_CHECK_INTERVAL_MINUTES", "5"))
+	if slaCheckMinutes < 1 {
+		slaCheckMinutes = 5
+	}
 
 	return &Config{
 		DBHost:                 getEnv("DB_HOST", "localhost"),
@@ -49,6 +65,12 @@ func LoadConfig() *Config {
 		CORSAllowedOrigins:     getEnv("CORS_ALLOWED_ORIGINS", "*"),
 		CORSAllowedMethods:     getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"),
 		APIHost:                getEnv("API_HOST", "localhost"),
+		JobWorkers:             jobWorkers,
+		JobRetention:           time.Duration(jobRetentionDays) * 24 * time.Hour,
+		SlaCheckInterval:       time.Duration(slaCheckMinutes) * time.Minute,
+		BusinessTimezone:       getEnv("BUSINESS_TIMEZONE", "Asia/Jakarta"),
+		WorkingDays:            getEnv("WORKING_DAYS", "mon,tue,wed,thu,fri,sat"),
+		WorkingHours:           getEnv("WORKING_HOURS", "08:00-17:00"),
 	}
 }
 
@@ -13,9 +13,10 @@ import (
 var DB *gorm.DB
 
 func ConnectDatabase(config *Config) {
+	// Sessions use the business timezone, so days computed by the database are the warehouse's days
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		config.DBHost, config.DBUser, config.DBPassword, config.DBName, config.DBPort, config.DBSSLMode,
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
+		config.DBHost, config.DBUser, config.DBPassword, config.DBName, config.DBPort, config.DBSSLMode, config.BusinessTimezone,
 	)
 
 	maxRetries := 10
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AuditLogController struct {
+	DB *gorm.DB
+}
+
+// NewAuditLogController creates a new audit log controller
+func NewAuditLogController(db *gorm.DB) *AuditLogController {
+	return &AuditLogController{DB: db}
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit logs
+// @Description Get the changes made to boxes, channels, expeditions, stores and users, newest first, with the user who made them, the changed fields and the client IP. Supports pagination and optional user, entity, action and date range filtering. (only superadmin can access)
+// @Tags audit-logs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param user_id query int false "Filter by ID of the user who made the change"
+// @Param entity query string false "Filter by entity (box, channel, expedition, store, user)"
+// @Param entity_id query int false "Filter by entity ID"
+// @Param action query string false "Filter by action (create, update, delete, update_status, reset_password, assign_role, remove_role)"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=AuditLogsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/audit-logs [get]
+func (alc *AuditLogController) GetAuditLogs(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var auditLogs []models.AuditLog
+	var total int64
+
+	query := alc.DB.Model(&models.AuditLog{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if userID := c.Query("user_id"); userID != "" {
+		parsedUserID, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user_id", "user_id must be a number")
+			return
+		}
+		query = query.Where("actor_id = ?", parsedUserID)
+	}
+
+	if entity := c.Query("entity"); entity != "" {
+		query = query.Where("entity = ?", entity)
+	}
+
+	if entityID := c.Query("entity_id"); entityID != "" {
+		parsedEntityID, err := strconv.ParseUint(entityID, 10, 64)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid entity_id", "entity_id must be a number")
+			return
+		}
+		query = query.Where("entity_id = ?", parsedEntityID)
+	}
+
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count audit logs", err.Error())
+		return
+	}
+
+	// Deleted users are still shown as the actor of the changes they made
+	if err := query.Preload("Actor", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Order("id DESC").Limit(limit).Offset(offset).Find(&auditLogs).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve audit logs", err.Error())
+		return
+	}
+
+	response := AuditLogsListResponse{
+		AuditLogs: make([]models.AuditLogResponse, len(auditLogs)),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+	for i, auditLog := range auditLogs {
+		response.AuditLogs[i] = auditLog.ToAuditLogResponse()
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Audit logs retrieved successfully", response)
+}
+
+// recordAudit records a change made by the current user in the audit log, with the client IP of the request.
+// It must be called inside the transaction that made the change.
+func recordAudit(c *gin.Context, tx *gorm.DB, action, entity string, entityID uint, before, after interface{}) error {
+	var actorID *uint
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(uint); ok {
+			actorID = &id
+		}
+	}
+
+	entry, err := models.NewAuditLog(action, entity, entityID, before, after, actorID, c.ClientIP())
+	if err != nil {
+		return err
+	}
+	return models.RecordAuditLog(tx, entry)
+}
+
+// Request/Response structs
+type AuditLogsListResponse struct {
+	AuditLogs  []models.AuditLogResponse `json:"audit_logs"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
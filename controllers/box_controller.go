@@ -153,11 +153,18 @@ func (bc *BoxController) UpdateBox(c *gin.Context) {
 		return
 	}
 
+	before := box
+
 	// Update box fields
 	box.Code = req.Code
 	box.Name = req.Name
 
-	if err := bc.DB.Save(&box).Error; err != nil {
+	if err := bc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&box).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdate, models.AuditEntityBox, box.ID, before, box)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update box", err.Error())
 		return
 	}
@@ -187,7 +194,12 @@ func (bc *BoxController) RemoveBox(c *gin.Context) {
 		return
 	}
 
-	if err := bc.DB.Delete(&box).Error; err != nil {
+	if err := bc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&box).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionDelete, models.AuditEntityBox, box.ID, box, nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete box", err.Error())
 		return
 	}
@@ -231,7 +243,12 @@ func (bc *BoxController) CreateBox(c *gin.Context) {
 	}
 
 	// Create a new box and return the response
-	if err := bc.DB.Create(&box).Error; err != nil {
+	if err := bc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&box).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionCreate, models.AuditEntityBox, box.ID, nil, box)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create box", err.Error())
 		return
 	}
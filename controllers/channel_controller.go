@@ -153,11 +153,18 @@ func (cc *ChannelController) UpdateChannel(c *gin.Context) {
 		return
 	}
 
+	before := channel
+
 	// Update channel fields
 	channel.Code = req.Code
 	channel.Name = req.Name
 
-	if err := cc.DB.Save(&channel).Error; err != nil {
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&channel).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdate, models.AuditEntityChannel, channel.ID, before, channel)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update channel", err.Error())
 		return
 	}
@@ -187,7 +194,12 @@ func (cc *ChannelController) RemoveChannel(c *gin.Context) {
 		return
 	}
 
-	if err := cc.DB.Delete(&channel).Error; err != nil {
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&channel).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionDelete, models.AuditEntityChannel, channel.ID, channel, nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete channel", err.Error())
 		return
 	}
@@ -231,7 +243,12 @@ func (cc *ChannelController) CreateChannel(c *gin.Context) {
 	}
 
 	// Create a new channel and return the response
-	if err := cc.DB.Create(&channel).Error; err != nil {
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&channel).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionCreate, models.AuditEntityChannel, channel.ID, nil, channel)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create channel", err.Error())
 		return
 	}
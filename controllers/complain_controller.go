@@ -0,0 +1,641 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ComplainController struct {
+	DB *gorm.DB
+}
+
+// NewComplainController creates a new complain controller
+func NewComplainController(db *gorm.DB) *ComplainController {
+	return &ComplainController{DB: db}
+}
+
+// CreateComplain godoc
+// @Summary Create complain
+// @Description Open a complain for an order identified by tracking or order ginee ID. The complain code is generated per day, and the order, outbound and QC records of the tracking are marked as complained.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateComplainRequest true "Create complain request"
+// @Success 201 {object} utils.Response{data=models.ComplainResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/complains [post]
+func (cc *ComplainController) CreateComplain(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateComplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Tracking = strings.TrimSpace(req.Tracking)
+	req.OrderGineeID = strings.TrimSpace(req.OrderGineeID)
+	if req.Tracking == "" && req.OrderGineeID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", "either tracking or order_ginee_id is required")
+		return
+	}
+
+	// Complete the order identifiers when the order is known
+	var order models.Order
+	query := cc.DB.Model(&models.Order{})
+	if req.Tracking != "" {
+		query = query.Where("tracking = ?", req.Tracking)
+	} else {
+		query = query.Where("order_ginee_id = ?", req.OrderGineeID)
+	}
+	if err := query.First(&order).Error; err == nil {
+		req.Tracking = order.Tracking
+		req.OrderGineeID = order.OrderGineeID
+	}
+
+	if err := cc.validateComplainReferences(req.ChannelID, req.StoreID, req.ProductDetails, req.UserDetails); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid complain", err.Error())
+		return
+	}
+
+	var creator models.User
+	if err := cc.DB.First(&creator, creatorID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find user", err.Error())
+		return
+	}
+
+	complain := models.Complain{
+		Tracking:     req.Tracking,
+		OrderGineeID: req.OrderGineeID,
+		ChannelID:    req.ChannelID,
+		StoreID:      req.StoreID,
+		CreatorID:    creatorID,
+		Description:  strings.TrimSpace(req.Description),
+	}
+	complain.ProductDetails, complain.UserDetails, complain.TotalFee = buildComplainDetails(req.ProductDetails, req.UserDetails)
+
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		code, err := utils.GenerateComplainCode(tx, creator.Username)
+		if err != nil {
+			return err
+		}
+		complain.Code = code
+
+		if err := tx.Create(&complain).Error; err != nil {
+			return err
+		}
+		return models.SyncComplainedFlags(tx, complain.Tracking, &creatorID, "Complain "+complain.Code+" created")
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create complain", err.Error())
+		return
+	}
+
+	cc.respondWithComplain(c, http.StatusCreated, "Complain created successfully", complain.ID)
+}
+
+// GetComplains godoc
+// @Summary Get all complains
+// @Description Get list of complains with pagination, optional status, checked and date range filtering, and search by code, tracking or order ginee ID.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (open, solved)"
+// @Param checked query bool false "Filter by checked flag"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Param search query string false "Search by code, tracking or order ginee ID"
+// @Success 200 {object} utils.Response{data=ComplainsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/complains [get]
+func (cc *ComplainController) GetComplains(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	search := strings.TrimSpace(c.Query("search"))
+	status := c.Query("status")
+	checked := c.Query("checked")
+
+	var complains []models.Complain
+	var total int64
+
+	query := cc.DB.Model(&models.Complain{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	switch status {
+	case "":
+	case "open":
+		query = query.Where("solved_at IS NULL")
+	case "solved":
+		query = query.Where("solved_at IS NOT NULL")
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid status", "status must be 'open' or 'solved'")
+		return
+	}
+
+	if checked != "" {
+		isChecked, err := strconv.ParseBool(checked)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid checked filter", "checked must be true or false")
+			return
+		}
+		query = query.Where("checked = ?", isChecked)
+	}
+
+	if search != "" {
+		query = query.Where("code ILIKE ? OR tracking ILIKE ? OR order_ginee_id ILIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count complains", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("ProductDetails.Product").
+		Preload("UserDetails.User").
+		Preload("Channel").
+		Preload("Store").
+		Preload("Creator").
+		Find(&complains).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve complains", err.Error())
+		return
+	}
+
+	response := ComplainsListResponse{
+		Complains: models.ToComplainResponses(complains),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Complains retrieved successfully", response)
+}
+
+// GetComplain godoc
+// @Summary Get complain by ID
+// @Description Get a complain with its product and operator details and the related order.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Complain ID"
+// @Success 200 {object} utils.Response{data=models.ComplainResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/complains/{id} [get]
+func (cc *ComplainController) GetComplain(c *gin.Context) {
+	complainID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid complain ID", "Complain ID must be a valid number")
+		return
+	}
+
+	cc.respondWithComplain(c, http.StatusOK, "Complain retrieved successfully", uint(complainID))
+}
+
+// UpdateComplain godoc
+// @Summary Update complain
+// @Description Update the description, channel, store, products and complained operators of an unsolved complain whose fee charges have not been reviewed. Details are replaced by the given lists.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Complain ID"
+// @Param request body UpdateComplainRequest true "Update complain request"
+// @Success 200 {object} utils.Response{data=models.ComplainResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/complains/{id} [put]
+func (cc *ComplainController) UpdateComplain(c *gin.Context) {
+	var req UpdateComplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	complain, ok := cc.findComplain(c)
+	if !ok {
+		return
+	}
+
+	if complain.SolvedAt != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Complain already solved", "solved complains can no longer be updated")
+		return
+	}
+
+	if err := cc.validateComplainReferences(req.ChannelID, req.StoreID, req.ProductDetails, req.UserDetails); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid complain", err.Error())
+		return
+	}
+
+	complain.ChannelID = req.ChannelID
+	complain.StoreID = req.StoreID
+	complain.Description = strings.TrimSpace(req.Description)
+	productDetails, userDetails, totalFee := buildComplainDetails(req.ProductDetails, req.UserDetails)
+	complain.TotalFee = totalFee
+
+	statusCode := http.StatusInternalServerError
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		// Reviewed fee charges are part of the payroll ledger and must not be replaced. The charges are locked so
+		// they cannot be reviewed while the complain is updated.
+		var charges []models.ComplainUserDetail
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("complain_id = ?", complain.ID).Find(&charges).Error; err != nil {
+			return err
+		}
+		for _, charge := range charges {
+			if charge.FeeStatus != models.FeeChargeStatusPending {
+				statusCode = http.StatusConflict
+				return fmt.Errorf("complains with approved or waived fee charges can no longer be updated")
+			}
+		}
+
+		if err := tx.Where("complain_id = ?", complain.ID).Delete(&models.ComplainProductDetail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("complain_id = ?", complain.ID).Delete(&models.ComplainUserDetail{}).Error; err != nil {
+			return err
+		}
+
+		for i := range productDetails {
+			productDetails[i].ComplainID = complain.ID
+		}
+		for i := range userDetails {
+			userDetails[i].ComplainID = complain.ID
+		}
+		if len(productDetails) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&productDetails).Error; err != nil {
+				return err
+			}
+		}
+		if len(userDetails) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&userDetails).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit(clause.Associations).Save(complain).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to update complain", err.Error())
+		return
+	}
+
+	cc.respondWithComplain(c, http.StatusOK, "Complain updated successfully", complain.ID)
+}
+
+// CheckComplain godoc
+// @Summary Check complain
+// @Description Mark a complain as checked after the case has been reviewed. A complain must be checked before it can be solved.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Complain ID"
+// @Success 200 {object} utils.Response{data=models.ComplainResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/complains/{id}/check [put]
+func (cc *ComplainController) CheckComplain(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	checkerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	complain, ok := cc.findComplain(c)
+	if !ok {
+		return
+	}
+
+	if complain.Checked {
+		utils.ErrorResponse(c, http.StatusConflict, "Complain already checked", "complain has already been checked")
+		return
+	}
+
+	now := time.Now()
+	complain.Checked = true
+	complain.CheckerID = &checkerID
+	complain.CheckedAt = &now
+
+	if err := cc.DB.Omit(clause.Associations).Save(complain).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to check complain", err.Error())
+		return
+	}
+
+	cc.respondWithComplain(c, http.StatusOK, "Complain checked successfully", complain.ID)
+}
+
+// SolveComplain godoc
+// @Summary Solve complain
+// @Description Close a checked complain with its solution. The complained flags of the tracking are cleared when it has no other unsolved complain.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Complain ID"
+// @Param request body SolveComplainRequest true "Solve complain request"
+// @Success 200 {object} utils.Response{data=models.ComplainResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/complains/{id}/solve [put]
+func (cc *ComplainController) SolveComplain(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	solverID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req SolveComplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Solution = strings.TrimSpace(req.Solution)
+	if req.Solution == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid solution", "solution is required")
+		return
+	}
+
+	complain, ok := cc.findComplain(c)
+	if !ok {
+		return
+	}
+
+	if complain.SolvedAt != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Complain already solved", "complain has already been solved")
+		return
+	}
+
+	if !complain.Checked {
+		utils.ErrorResponse(c, http.StatusConflict, "Complain not checked", "complain must be checked before it can be solved")
+		return
+	}
+
+	now := time.Now()
+	complain.Solution = req.Solution
+	complain.SolverID = &solverID
+	complain.SolvedAt = &now
+
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(complain).Error; err != nil {
+			return err
+		}
+		return models.SyncComplainedFlags(tx, complain.Tracking, &solverID, "Complain "+complain.Code+" solved")
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to solve complain", err.Error())
+		return
+	}
+
+	cc.respondWithComplain(c, http.StatusOK, "Complain solved successfully", complain.ID)
+}
+
+// DeleteComplain godoc
+// @Summary Delete complain
+// @Description Delete a complain with its details. Complains with approved or waived fee charges cannot be deleted. The complained flags of the tracking are cleared when it has no other unsolved complain.
+// @Tags complains
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Complain ID"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/complains/{id} [delete]
+func (cc *ComplainController) DeleteComplain(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	deleterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	complain, ok := cc.findComplain(c)
+	if !ok {
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := cc.DB.Transaction(func(tx *gorm.DB) error {
+		// Reviewed fee charges are part of the payroll ledger and must not be deleted. The charges are locked so
+		// they cannot be reviewed while the complain is deleted.
+		var charges []models.ComplainUserDetail
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("complain_id = ?", complain.ID).Find(&charges).Error; err != nil {
+			return err
+		}
+		for _, charge := range charges {
+			if charge.FeeStatus != models.FeeChargeStatusPending {
+				statusCode = http.StatusConflict
+				return fmt.Errorf("complains with approved or waived fee charges can no longer be deleted")
+			}
+		}
+
+		if err := tx.Where("complain_id = ?", complain.ID).Delete(&models.ComplainProductDetail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("complain_id = ?", complain.ID).Delete(&models.ComplainUserDetail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(complain).Error; err != nil {
+			return err
+		}
+		return models.SyncComplainedFlags(tx, complain.Tracking, &deleterID, "Complain "+complain.Code+" deleted")
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to delete complain", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Complain deleted successfully", nil)
+}
+
+// findComplain loads the complain of the id path parameter, responding with an error when not found
+func (cc *ComplainController) findComplain(c *gin.Context) (*models.Complain, bool) {
+	var complain models.Complain
+	if err := cc.DB.First(&complain, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Complain not found", "no complain found with the specified ID")
+			return nil, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find complain", err.Error())
+		return nil, false
+	}
+
+	return &complain, true
+}
+
+// validateComplainReferences verifies that the channel, store, products and complained operators exist
+func (cc *ComplainController) validateComplainReferences(channelID, storeID uint, products []ComplainProductRequest, operators []ComplainOperatorRequest) error {
+	var count int64
+	if cc.DB.Model(&models.Channel{}).Where("id = ?", channelID).Count(&count); count == 0 {
+		return fmt.Errorf("channel %d not found", channelID)
+	}
+	if cc.DB.Model(&models.Store{}).Where("id = ?", storeID).Count(&count); count == 0 {
+		return fmt.Errorf("store %d not found", storeID)
+	}
+
+	for _, product := range products {
+		if cc.DB.Model(&models.Product{}).Where("id = ?", product.ProductID).Count(&count); count == 0 {
+			return fmt.Errorf("product %d not found", product.ProductID)
+		}
+	}
+	for _, operator := range operators {
+		if cc.DB.Model(&models.User{}).Where("id = ?", operator.ComplainedOperatorID).Count(&count); count == 0 {
+			return fmt.Errorf("operator %d not found", operator.ComplainedOperatorID)
+		}
+	}
+
+	return nil
+}
+
+// buildComplainDetails converts the requested details to models and sums the operator fee charges
+func buildComplainDetails(products []ComplainProductRequest, operators []ComplainOperatorRequest) ([]models.ComplainProductDetail, []models.ComplainUserDetail, uint) {
+	productDetails := make([]models.ComplainProductDetail, len(products))
+	for i, product := range products {
+		productDetails[i] = models.ComplainProductDetail{
+			ProductID: product.ProductID,
+			Quantity:  product.Quantity,
+		}
+	}
+
+	var totalFee uint
+	userDetails := make([]models.ComplainUserDetail, len(operators))
+	for i, operator := range operators {
+		userDetails[i] = models.ComplainUserDetail{
+			ComplainedOperatorID: operator.ComplainedOperatorID,
+			FeeCharge:            operator.FeeCharge,
+			FeeStatus:            models.FeeChargeStatusPending,
+		}
+		totalFee += operator.FeeCharge
+	}
+
+	return productDetails, userDetails, totalFee
+}
+
+// respondWithComplain loads the complain with its details and order
+func (cc *ComplainController) respondWithComplain(c *gin.Context, statusCode int, message string, complainID uint) {
+	var complain models.Complain
+	if err := cc.DB.
+		Preload("ProductDetails.Product").
+		Preload("UserDetails.User").
+		Preload("Channel").
+		Preload("Store").
+		Preload("Creator").
+		First(&complain, complainID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Complain not found", "no complain found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve complain", err.Error())
+		return
+	}
+
+	complain.Order, _ = models.ResolveOrder(cc.DB, complain.Tracking, "")
+
+	utils.SuccessResponse(c, statusCode, message, complain.ToComplainResponse())
+}
+
+// Request/Response structs
+type CreateComplainRequest struct {
+	Tracking       string                    `json:"tracking" example:"JP1234567890"`
+	OrderGineeID   string                    `json:"order_ginee_id" example:"2510080001"`
+	ChannelID      uint                      `json:"channel_id" binding:"required" example:"1"`
+	StoreID        uint                      `json:"store_id" binding:"required" example:"1"`
+	Description    string                    `json:"description" binding:"required" example:"Wrong variant sent"`
+	ProductDetails []ComplainProductRequest  `json:"product_details" binding:"dive"`
+	UserDetails    []ComplainOperatorRequest `json:"user_details" binding:"dive"`
+}
+
+type UpdateComplainRequest struct {
+	ChannelID      uint                      `json:"channel_id" binding:"required" example:"1"`
+	StoreID        uint                      `json:"store_id" binding:"required" example:"1"`
+	Description    string                    `json:"description" binding:"required" example:"Wrong variant sent"`
+	ProductDetails []ComplainProductRequest  `json:"product_details" binding:"dive"`
+	UserDetails    []ComplainOperatorRequest `json:"user_details" binding:"dive"`
+}
+
+type ComplainProductRequest struct {
+	ProductID uint `json:"product_id" binding:"required" example:"1"`
+	Quantity  int  `json:"quantity" binding:"required,min=1" example:"1"`
+}
+
+type ComplainOperatorRequest struct {
+	ComplainedOperatorID uint `json:"complained_operator_id" binding:"required" example:"1"`
+	FeeCharge            uint `json:"fee_charge" example:"5000"`
+}
+
+type SolveComplainRequest struct {
+	Solution string `json:"solution" binding:"required" example:"Replacement sent to buyer"`
+}
+
+type ComplainsListResponse struct {
+	Complains  []models.ComplainResponse `json:"complains"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
@@ -153,13 +153,20 @@ func (ec *ExpeditionController) UpdateExpedition(c *gin.Context) {
 		return
 	}
 
+	before := expedition
+
 	// Update expedition fields
 	expedition.Code = req.Code
 	expedition.Name = req.Name
 	expedition.Color = req.Color
 	expedition.Slug = req.Slug
 
-	if err := ec.DB.Save(&expedition).Error; err != nil {
+	if err := ec.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&expedition).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdate, models.AuditEntityExpedition, expedition.ID, before, expedition)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update expedition", err.Error())
 		return
 	}
@@ -189,7 +196,12 @@ func (ec *ExpeditionController) RemoveExpedition(c *gin.Context) {
 		return
 	}
 
-	if err := ec.DB.Delete(&expedition).Error; err != nil {
+	if err := ec.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&expedition).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionDelete, models.AuditEntityExpedition, expedition.ID, expedition, nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete expedition", err.Error())
 		return
 	}
@@ -238,7 +250,12 @@ func (ec *ExpeditionController) CreateExpedition(c *gin.Context) {
 	}
 
 	// Create a new expedition and return the response
-	if err := ec.DB.Create(&expedition).Error; err != nil {
+	if err := ec.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&expedition).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionCreate, models.AuditEntityExpedition, expedition.ID, nil, expedition)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create expedition", err.Error())
 		return
 	}
@@ -0,0 +1,511 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeeChargeController struct {
+	DB *gorm.DB
+}
+
+// NewFeeChargeController creates a new fee charge controller
+func NewFeeChargeController(db *gorm.DB) *FeeChargeController {
+	return &FeeChargeController{DB: db}
+}
+
+// GetFeeCharges godoc
+// @Summary Get operator fee charges
+// @Description Get the fee charges of complained operators for a monthly pay period, with optional operator and review status filtering. Charges belong to the period in which the complain was created.
+// @Tags fee-charges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param period query string false "Pay period (YYYY-MM format), defaults to the current month"
+// @Param operator_id query int false "Filter by complained operator ID"
+// @Param status query string false "Filter by review status (pending, approved, waived)"
+// @Success 200 {object} utils.Response{data=FeeChargesListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/fee-charges [get]
+func (fc *FeeChargeController) GetFeeCharges(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	period, start, end, ok := parsePayPeriod(c)
+	if !ok {
+		return
+	}
+
+	var charges []models.ComplainUserDetail
+	var total int64
+
+	query := fc.periodCharges(start, end)
+
+	if operatorID := c.Query("operator_id"); operatorID != "" {
+		query = query.Where("complain_user_details.complained_operator_id = ?", operatorID)
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("complain_user_details.fee_status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count fee charges", err.Error())
+		return
+	}
+
+	if err := query.Order("complain_user_details.id DESC").Limit(limit).Offset(offset).
+		Preload("Complain").
+		Preload("User").
+		Find(&charges).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve fee charges", err.Error())
+		return
+	}
+
+	chargeResponses := make([]FeeChargeResponse, len(charges))
+	for i, charge := range charges {
+		chargeResponses[i] = toFeeChargeResponse(charge)
+	}
+
+	response := FeeChargesListResponse{
+		Period:     period,
+		FeeCharges: chargeResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Fee charges retrieved successfully", response)
+}
+
+// GetFeeChargeLedger godoc
+// @Summary Get operator fee charge ledger
+// @Description Get the fee charge totals per operator for a monthly pay period, split by review status, with a reconciliation of the charges against the complain total fees.
+// @Tags fee-charges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Pay period (YYYY-MM format), defaults to the current month"
+// @Success 200 {object} utils.Response{data=FeeChargeLedgerResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/fee-charges/ledger [get]
+func (fc *FeeChargeController) GetFeeChargeLedger(c *gin.Context) {
+	period, start, end, ok := parsePayPeriod(c)
+	if !ok {
+		return
+	}
+
+	ledger, err := fc.buildLedger(period, start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to build fee charge ledger", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Fee charge ledger retrieved successfully", ledger)
+}
+
+// ExportFeeChargeLedger godoc
+// @Summary Export payroll deductions
+// @Description Queue a background job that exports the fee charge ledger of a monthly pay period as CSV for payroll. The deduction of an operator is the total of the approved charges. The job fails while the charges do not reconcile with the complain total fees. Poll GET /api/jobs/{id} and download the file from GET /api/jobs/{id}/download.
+// @Tags fee-charges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Pay period (YYYY-MM format), defaults to the current month"
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/fee-charges/ledger/export [post]
+func (fc *FeeChargeController) ExportFeeChargeLedger(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	period, _, _, ok := parsePayPeriod(c)
+	if !ok {
+		return
+	}
+
+	queueJob(c, fc.DB, models.JobTypeExportFeeCharges, FeeChargeExportJobParams{Period: period}, requesterID, "Payroll deductions export queued")
+}
+
+// RunExportFeeChargesJob writes the payroll deductions of a queued export as the CSV file of the job
+func (fc *FeeChargeController) RunExportFeeChargesJob(ctx context.Context, task *jobs.Task) error {
+	var params FeeChargeExportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	start, end, err := payPeriodRange(params.Period)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+
+	ledger, err := fc.buildLedger(params.Period, start, end)
+	if err != nil {
+		return err
+	}
+
+	if !ledger.Reconciliation.Reconciled {
+		return jobs.Permanent(fmt.Errorf("fee charges do not reconcile, complains with total fee mismatch: %s", strings.Join(ledger.Reconciliation.MismatchedComplains, ", ")))
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"Period", "Operator ID", "Username", "Name", "Charges", "Total Charged", "Approved (Deduction)", "Waived", "Pending"})
+	for _, line := range ledger.Operators {
+		writer.Write([]string{
+			params.Period,
+			strconv.FormatUint(uint64(line.OperatorID), 10),
+			line.Username,
+			line.Name,
+			strconv.Itoa(line.Charges),
+			strconv.FormatUint(line.TotalCharged, 10),
+			strconv.FormatUint(line.Approved, 10),
+			strconv.FormatUint(line.Waived, 10),
+			strconv.FormatUint(line.Pending, 10),
+		})
+	}
+	writer.Write([]string{
+		params.Period,
+		"",
+		"",
+		"TOTAL",
+		strconv.Itoa(ledger.Totals.Charges),
+		strconv.FormatUint(ledger.Totals.TotalCharged, 10),
+		strconv.FormatUint(ledger.Totals.Approved, 10),
+		strconv.FormatUint(ledger.Totals.Waived, 10),
+		strconv.FormatUint(ledger.Totals.Pending, 10),
+	})
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	task.SetFile(fmt.Sprintf("payroll-deductions-%s.csv", params.Period), "text/csv", buffer.Bytes())
+	return task.SetResult(ledger.Totals)
+}
+
+// ApproveFeeCharge godoc
+// @Summary Approve fee charge
+// @Description Approve an operator fee charge so it is deducted in payroll.
+// @Tags fee-charges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Fee charge (complain user detail) ID"
+// @Success 200 {object} utils.Response{data=FeeChargeResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/fee-charges/{id}/approve [put]
+func (fc *FeeChargeController) ApproveFeeCharge(c *gin.Context) {
+	fc.reviewFeeCharge(c, models.FeeChargeStatusApproved, "")
+}
+
+// WaiveFeeCharge godoc
+// @Summary Waive fee charge
+// @Description Waive an operator fee charge with a reason so it is not deducted in payroll.
+// @Tags fee-charges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Fee charge (complain user detail) ID"
+// @Param request body WaiveFeeChargeRequest true "Waive fee charge request"
+// @Success 200 {object} utils.Response{data=FeeChargeResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/fee-charges/{id}/waive [put]
+func (fc *FeeChargeController) WaiveFeeCharge(c *gin.Context) {
+	var req WaiveFeeChargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid reason", "reason is required")
+		return
+	}
+
+	fc.reviewFeeCharge(c, models.FeeChargeStatusWaived, req.Reason)
+}
+
+// reviewFeeCharge sets the review status of the fee charge of the id path parameter
+func (fc *FeeChargeController) reviewFeeCharge(c *gin.Context, status string, reason string) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	reviewerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var charge models.ComplainUserDetail
+	statusCode := http.StatusInternalServerError
+	if err := fc.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the charge so concurrent reviews cannot flip it between approved and waived
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&charge, c.Param("id")).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no fee charge found with the specified ID")
+			}
+			return err
+		}
+
+		if charge.FeeStatus == status {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("fee charge is already '%s'", status)
+		}
+
+		now := time.Now()
+		charge.FeeStatus = status
+		charge.ReviewerID = &reviewerID
+		charge.ReviewedAt = &now
+		charge.WaiveReason = reason
+
+		return tx.Omit(clause.Associations).Save(&charge).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to review fee charge", err.Error())
+		return
+	}
+
+	fc.DB.Preload("Complain").Preload("User").First(&charge, charge.ID)
+
+	utils.SuccessResponse(c, http.StatusOK, fmt.Sprintf("Fee charge %s successfully", status), toFeeChargeResponse(charge))
+}
+
+// periodCharges returns the query of fee charges whose complain was created within the period
+func (fc *FeeChargeController) periodCharges(start, end time.Time) *gorm.DB {
+	return fc.DB.Model(&models.ComplainUserDetail{}).
+		Joins("JOIN complains ON complains.id = complain_user_details.complain_id AND complains.deleted_at IS NULL").
+		Where("complains.created_at >= ? AND complains.created_at < ?", start, end)
+}
+
+// buildLedger aggregates the fee charges of the period per operator and reconciles them with the complain total fees
+func (fc *FeeChargeController) buildLedger(period string, start, end time.Time) (*FeeChargeLedgerResponse, error) {
+	var lines []FeeChargeLedgerLine
+	if err := fc.periodCharges(start, end).
+		Select(`complain_user_details.complained_operator_id AS operator_id,
+			users.username AS username,
+			users.name AS name,
+			COUNT(*) AS charges,
+			COALESCE(SUM(complain_user_details.fee_charge), 0) AS total_charged,
+			COALESCE(SUM(CASE WHEN complain_user_details.fee_status = ? THEN complain_user_details.fee_charge ELSE 0 END), 0) AS approved,
+			COALESCE(SUM(CASE WHEN complain_user_details.fee_status = ? THEN complain_user_details.fee_charge ELSE 0 END), 0) AS waived,
+			COALESCE(SUM(CASE WHEN complain_user_details.fee_status = ? THEN complain_user_details.fee_charge ELSE 0 END), 0) AS pending`,
+			models.FeeChargeStatusApproved, models.FeeChargeStatusWaived, models.FeeChargeStatusPending).
+		Joins("LEFT JOIN users ON users.id = complain_user_details.complained_operator_id").
+		Group("complain_user_details.complained_operator_id, users.username, users.name").
+		Order("users.name ASC").
+		Scan(&lines).Error; err != nil {
+		return nil, err
+	}
+
+	ledger := &FeeChargeLedgerResponse{
+		Period:    period,
+		Operators: lines,
+	}
+	if ledger.Operators == nil {
+		ledger.Operators = []FeeChargeLedgerLine{}
+	}
+	for _, line := range lines {
+		ledger.Totals.Charges += line.Charges
+		ledger.Totals.TotalCharged += line.TotalCharged
+		ledger.Totals.Approved += line.Approved
+		ledger.Totals.Waived += line.Waived
+		ledger.Totals.Pending += line.Pending
+	}
+
+	// Every complain total fee must equal the sum of its operator charges
+	var complainTotal uint64
+	if err := fc.DB.Model(&models.Complain{}).
+		Select("COALESCE(SUM(total_fee), 0)").
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Scan(&complainTotal).Error; err != nil {
+		return nil, err
+	}
+
+	var mismatched []string
+	if err := fc.DB.Model(&models.Complain{}).
+		Joins("LEFT JOIN complain_user_details ON complain_user_details.complain_id = complains.id AND complain_user_details.deleted_at IS NULL").
+		Where("complains.created_at >= ? AND complains.created_at < ?", start, end).
+		Group("complains.id, complains.code, complains.total_fee").
+		Having("COALESCE(complains.total_fee, 0) <> COALESCE(SUM(complain_user_details.fee_charge), 0)").
+		Order("complains.code ASC").
+		Pluck("complains.code", &mismatched).Error; err != nil {
+		return nil, err
+	}
+	if mismatched == nil {
+		mismatched = []string{}
+	}
+
+	ledger.Reconciliation = FeeChargeReconciliation{
+		ComplainTotalFee:    complainTotal,
+		TotalCharged:        ledger.Totals.TotalCharged,
+		Reconciled:          complainTotal == ledger.Totals.TotalCharged && len(mismatched) == 0,
+		MismatchedComplains: mismatched,
+	}
+
+	return ledger, nil
+}
+
+// parsePayPeriod parses the period query parameter (YYYY-MM) into the month boundaries, defaulting to the current month.
+// It writes the error response and returns false when the period is invalid.
+func parsePayPeriod(c *gin.Context) (string, time.Time, time.Time, bool) {
+	period := c.Query("period")
+	if period == "" {
+		period = utils.BusinessNow().Format("2006-01")
+	}
+
+	start, end, err := payPeriodRange(period)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid period format", err.Error())
+		return "", time.Time{}, time.Time{}, false
+	}
+
+	return period, start, end, true
+}
+
+// payPeriodRange returns the start and the exclusive end of a monthly pay period in YYYY-MM format
+func payPeriodRange(period string) (time.Time, time.Time, error) {
+	start, err := utils.ParseBusinessTime("2006-01", period)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("period must be in YYYY-MM format")
+	}
+
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// toFeeChargeResponse converts a complain user detail with its complain and operator to a ledger entry
+func toFeeChargeResponse(charge models.ComplainUserDetail) FeeChargeResponse {
+	response := FeeChargeResponse{
+		ID:                   charge.ID,
+		ComplainID:           charge.ComplainID,
+		ComplainCode:         charge.Complain.Code,
+		Tracking:             charge.Complain.Tracking,
+		ComplainedAt:         charge.Complain.CreatedAt,
+		ComplainedOperatorID: charge.ComplainedOperatorID,
+		FeeCharge:            charge.FeeCharge,
+		FeeStatus:            charge.FeeStatus,
+		ReviewerID:           charge.ReviewerID,
+		ReviewedAt:           charge.ReviewedAt,
+		WaiveReason:          charge.WaiveReason,
+	}
+
+	// Include operator data if loaded
+	if charge.User != nil {
+		operatorResp := charge.User.ToUserResponse()
+		response.ComplainedOperator = &operatorResp
+	}
+
+	return response
+}
+
+// Request/Response structs
+type WaiveFeeChargeRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Damage caused by courier"`
+}
+
+type FeeChargeResponse struct {
+	ID                   uint                 `json:"id"`
+	ComplainID           uint                 `json:"complain_id"`
+	ComplainCode         string               `json:"complain_code"`
+	Tracking             string               `json:"tracking"`
+	ComplainedAt         time.Time            `json:"complained_at"`
+	ComplainedOperatorID uint                 `json:"complained_operator_id"`
+	FeeCharge            uint                 `json:"fee_charge"`
+	FeeStatus            string               `json:"fee_status"`
+	ReviewerID           *uint                `json:"reviewer_id"`
+	ReviewedAt           *time.Time           `json:"reviewed_at"`
+	WaiveReason          string               `json:"waive_reason"`
+	ComplainedOperator   *models.UserResponse `json:"complained_operator,omitempty"`
+}
+
+type FeeChargesListResponse struct {
+	Period     string                   `json:"period"`
+	FeeCharges []FeeChargeResponse      `json:"fee_charges"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
+
+type FeeChargeLedgerLine struct {
+	OperatorID   uint   `json:"operator_id"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	Charges      int    `json:"charges"`
+	TotalCharged uint64 `json:"total_charged"`
+	Approved     uint64 `json:"approved"`
+	Waived       uint64 `json:"waived"`
+	Pending      uint64 `json:"pending"`
+}
+
+type FeeChargeLedgerTotals struct {
+	Charges      int    `json:"charges"`
+	TotalCharged uint64 `json:"total_charged"`
+	Approved     uint64 `json:"approved"`
+	Waived       uint64 `json:"waived"`
+	Pending      uint64 `json:"pending"`
+}
+
+type FeeChargeReconciliation struct {
+	ComplainTotalFee    uint64   `json:"complain_total_fee"`
+	TotalCharged        uint64   `json:"total_charged"`
+	Reconciled          bool     `json:"reconciled"`
+	MismatchedComplains []string `json:"mismatched_complains"`
+}
+
+// FeeChargeExportJobParams is the pay period of a queued payroll deductions export
+type FeeChargeExportJobParams struct {
+	Period string `json:"period"`
+}
+
+type FeeChargeLedgerResponse struct {
+	Period         string                  `json:"period"`
+	Operators      []FeeChargeLedgerLine   `json:"operators"`
+	Totals         FeeChargeLedgerTotals   `json:"totals"`
+	Reconciliation FeeChargeReconciliation `json:"reconciliation"`
+}
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"livo-backend-2.0/utils"
+)
+
+// gineeColumnAliases maps order fields to the normalized header names used by Ginee exports, most preferred first.
+// Master SKU is preferred over the channel SKU because it is the warehouse SKU.
+var gineeColumnAliases = map[string][]string{
+	"order_ginee_id":   {"ordergineeid", "orderginee", "gineeorderid", "orderid", "ordernumber", "orderno", "nomorpesanan", "nopesanan", "idpesanan"},
+	"channel":          {"channel", "channelname", "marketplace", "platform"},
+	"store":            {"store", "storename", "shop", "shopname", "toko", "namatoko"},
+	"buyer":            {"buyer", "buyername", "recipient", "recipientname", "receiver", "receivername", "customername", "namapembeli", "penerima", "namapenerima"},
+	"address":          {"address", "recipientaddress", "shippingaddress", "receiveraddress", "fulladdress", "alamat", "alamatpenerima", "alamatpengiriman"},
+	"courier":          {"courier", "logistics", "logisticsprovider", "logisticschannel", "shippingprovider", "shippingcarrier", "kurir", "ekspedisi"},
+	"tracking":         {"tracking", "trackingnumber", "trackingno", "awb", "awbnumber", "waybill", "resi", "noresi", "nomorresi"},
+	"sku":              {"mastersku", "skumaster", "skuinduk", "sku", "productsku", "variationsku"},
+	"product_name":     {"productname", "itemname", "product", "namaproduk", "produk"},
+	"variant":          {"variant", "variantname", "variation", "variationname", "variasi"},
+	"quantity":         {"quantity", "qty", "itemquantity", "productquantity", "jumlah"},
+	"processing_limit": {"processinglimit", "processingdeadline", "shipbefore", "shipbydate", "latestshiptime", "shippingdeadline", "deadline", "batasproses", "bataspengiriman", "kirimsebelum"},
+}
+
+// gineeExportOrder is an order parsed from one or more rows of a Ginee export
+type gineeExportOrder struct {
+	Rows   []int
+	Order  CreateOrderRequest
+	Errors []string
+}
+
+// parseGineeOrderExport groups the rows of a Ginee order export into orders. Rows of the same order ID (or rows
+// without an order ID following an order) become the order details; order fields are taken from the first row
+// that has them. Missing or invalid fields are reported per order instead of failing the whole file.
+func parseGineeOrderExport(rows [][]string) ([]gineeExportOrder, error) {
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("the file must contain a header row and at least one order row")
+	}
+
+	// Map the fields to column indexes by their normalized header names
+	headers := make(map[string]int)
+	for i, header := range rows[0] {
+		name := normalizeGineeHeader(header)
+		if _, exists := headers[name]; !exists {
+			headers[name] = i
+		}
+	}
+	columns := make(map[string]int)
+	for field, aliases := range gineeColumnAliases {
+		for _, alias := range aliases {
+			if i, exists := headers[alias]; exists {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	for _, required := range []string{"order_ginee_id", "sku"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("column for '%s' not found in the header row", required)
+		}
+	}
+
+	cell := func(row []string, field string) string {
+		i, exists := columns[field]
+		if !exists || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var orders []gineeExportOrder
+	index := make(map[string]int)
+	current := -1
+
+	for i, row := range rows[1:] {
+		rowNumber := i + 2 // 1-based, after the header row
+
+		orderGineeID := cell(row, "order_ginee_id")
+		sku := cell(row, "sku")
+
+		// Skip blank lines
+		if orderGineeID == "" && sku == "" {
+			continue
+		}
+
+		switch {
+		case orderGineeID == "":
+			if current < 0 {
+				orders = append(orders, gineeExportOrder{Rows: []int{rowNumber}, Errors: []string{fmt.Sprintf("row %d: order ID is required", rowNumber)}})
+				continue
+			}
+		default:
+			if j, exists := index[orderGineeID]; exists {
+				current = j
+			} else {
+				current = len(orders)
+				index[orderGineeID] = current
+				orders = append(orders, gineeExportOrder{Order: CreateOrderRequest{OrderGineeID: orderGineeID}})
+			}
+		}
+
+		order := &orders[current]
+		order.Rows = append(order.Rows, rowNumber)
+
+		// Order fields are taken from the first row that has them
+		fill := func(target *string, field string) {
+			if *target == "" {
+				*target = cell(row, field)
+			}
+		}
+		fill(&order.Order.Channel, "channel")
+		fill(&order.Order.Store, "store")
+		fill(&order.Order.Buyer, "buyer")
+		fill(&order.Order.Address, "address")
+		fill(&order.Order.Courier, "courier")
+		fill(&order.Order.Tracking, "tracking")
+
+		if order.Order.ProcessingLimit == "" {
+			if value := cell(row, "processing_limit"); value != "" {
+				processingLimit, err := utils.ParseSpreadsheetTime(value)
+				if err != nil {
+					order.Errors = append(order.Errors, fmt.Sprintf("row %d: invalid processing limit '%s'", rowNumber, value))
+				} else {
+					order.Order.ProcessingLimit = processingLimit.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
+				}
+			}
+		}
+
+		if sku == "" {
+			order.Errors = append(order.Errors, fmt.Sprintf("row %d: sku is required", rowNumber))
+			continue
+		}
+
+		quantity := 1
+		if value := cell(row, "quantity"); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 1 || parsed != float64(int(parsed)) {
+				order.Errors = append(order.Errors, fmt.Sprintf("row %d: invalid quantity '%s'", rowNumber, value))
+				continue
+			}
+			quantity = int(parsed)
+		}
+
+		productName := cell(row, "product_name")
+		if productName == "" {
+			productName = sku
+		}
+
+		order.Order.OrderDetails = append(order.Order.OrderDetails, CreateOrderDetailRequest{
+			Sku:         sku,
+			ProductName: productName,
+			Variant:     cell(row, "variant"),
+			Quantity:    quantity,
+		})
+	}
+
+	for i := range orders {
+		orders[i].Errors = append(orders[i].Errors, validateImportedOrder(&orders[i].Order)...)
+	}
+
+	return orders, nil
+}
+
+// validateImportedOrder checks the fields that are required to create an order and its items
+func validateImportedOrder(order *CreateOrderRequest) []string {
+	var errors []string
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"order ID", order.OrderGineeID},
+		{"channel", order.Channel},
+		{"store", order.Store},
+		{"buyer", order.Buyer},
+		{"address", order.Address},
+		{"tracking", order.Tracking},
+		{"processing limit", order.ProcessingLimit},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			errors = append(errors, fmt.Sprintf("%s is required", field.name))
+		}
+	}
+
+	if len(order.OrderDetails) == 0 {
+		errors = append(errors, "order has no valid items")
+	}
+
+	// Items follow the same rules as the order detail requests of the API
+	for i, detail := range order.OrderDetails {
+		if strings.TrimSpace(detail.Sku) == "" {
+			errors = append(errors, fmt.Sprintf("item %d: sku is required", i+1))
+		}
+		if strings.TrimSpace(detail.ProductName) == "" {
+			errors = append(errors, fmt.Sprintf("item %d: product name is required", i+1))
+		}
+		if detail.Quantity < 1 {
+			errors = append(errors, fmt.Sprintf("item %d: quantity must be at least 1", i+1))
+		}
+	}
+
+	return errors
+}
+
+// normalizeGineeHeader lowercases a header name and drops everything but letters and digits
+func normalizeGineeHeader(header string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(header) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
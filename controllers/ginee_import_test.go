@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeGineeHeader(t *testing.T) {
+	tests := map[string]string{
+		"Order ID":          "orderid",
+		" Nomor Pesanan ":   "nomorpesanan",
+		"Master SKU":        "mastersku",
+		"AWB/Tracking No.":  "awbtrackingno",
+		"Qty (pcs)":         "qtypcs",
+		"Ship-By Date":      "shipbydate",
+		"Alamat_Pengiriman": "alamatpengiriman",
+	}
+
+	for header, want := range tests {
+		if got := normalizeGineeHeader(header); got != want {
+			t.Errorf("'%s': got '%s', want '%s'", header, got, want)
+		}
+	}
+}
+
+func TestParseGineeOrderExportResolvesHeaderAliases(t *testing.T) {
+	rows := [][]string{
+		// Indonesian headers, with the channel SKU before the master SKU
+		{"Nomor Pesanan", "Marketplace", "Nama Toko", "Nama Penerima", "Alamat Pengiriman", "Kurir", "No Resi", "SKU", "Master SKU", "Nama Produk", "Variasi", "Jumlah", "Batas Proses"},
+		{"INV-001", "Shopee", "Toko A", "Budi", "Jl. Merdeka 1", "JNE", "JNE001", "SHP-KAOS-M", "KAOS-M", "Kaos", "M", "2", "2026-08-12 15:00"},
+	}
+
+	orders, err := parseGineeOrderExport(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(orders))
+	}
+
+	order := orders[0]
+	if len(order.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", order.Errors)
+	}
+	got := order.Order
+	if got.OrderGineeID != "INV-001" || got.Channel != "Shopee" || got.Store != "Toko A" || got.Buyer != "Budi" ||
+		got.Address != "Jl. Merdeka 1" || got.Courier != "JNE" || got.Tracking != "JNE001" {
+		t.Errorf("order fields not mapped: %+v", got)
+	}
+	if got.ProcessingLimit != "2026-08-12 15:00:00" {
+		t.Errorf("processing limit: got '%s'", got.ProcessingLimit)
+	}
+	if len(got.OrderDetails) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(got.OrderDetails))
+	}
+	detail := got.OrderDetails[0]
+	// The master SKU is the warehouse SKU and wins over the channel SKU
+	if detail.Sku != "KAOS-M" || detail.ProductName != "Kaos" || detail.Variant != "M" || detail.Quantity != 2 {
+		t.Errorf("item not mapped: %+v", detail)
+	}
+}
+
+func TestParseGineeOrderExportRequiresOrderIDAndSkuColumns(t *testing.T) {
+	rows := [][]string{
+		{"Order ID", "Product Name"},
+		{"INV-001", "Kaos"},
+	}
+	if _, err := parseGineeOrderExport(rows); err == nil || !strings.Contains(err.Error(), "sku") {
+		t.Errorf("expected a missing sku column error, got: %v", err)
+	}
+
+	if _, err := parseGineeOrderExport(rows[:1]); err == nil {
+		t.Error("expected an error for a file without order rows")
+	}
+}
+
+func TestParseGineeOrderExportGroupsRows(t *testing.T) {
+	header := []string{"Order ID", "Channel", "Store", "Buyer", "Address", "Tracking", "SKU", "Product Name", "Quantity", "Ship Before"}
+	rows := [][]string{
+		header,
+		{"INV-001", "Shopee", "Toko A", "Budi", "Jl. Merdeka 1", "JNE001", "KAOS-M", "Kaos", "1", "2026-08-12 15:00"},
+		// Follow-up rows of the same order leave the order fields blank
+		{"", "", "", "", "", "", "TOPI", "Topi", "2", ""},
+		{"INV-002", "Tokopedia", "Toko B", "Sari", "Jl. Sudirman 2", "JNT002", "TAS", "", "", "2026-08-13 10:00"},
+		{},
+		// A later row of an earlier order joins that order
+		{"INV-001", "", "", "", "", "", "KAOS-M", "Kaos", "1", ""},
+		{"INV-003", "Lazada", "Toko C", "Andi", "Jl. Thamrin 3", "", "KAOS-L", "Kaos", "1.5", "besok"},
+	}
+
+	orders, err := parseGineeOrderExport(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 3 {
+		t.Fatalf("expected 3 orders, got %d", len(orders))
+	}
+
+	first := orders[0]
+	if len(first.Errors) != 0 {
+		t.Errorf("order INV-001: unexpected errors %v", first.Errors)
+	}
+	if want := []int{2, 3, 6}; !equalInts(first.Rows, want) {
+		t.Errorf("order INV-001: rows %v, want %v", first.Rows, want)
+	}
+	if len(first.Order.OrderDetails) != 3 || first.Order.OrderDetails[1].Sku != "TOPI" || first.Order.OrderDetails[1].Quantity != 2 {
+		t.Errorf("order INV-001: items %+v", first.Order.OrderDetails)
+	}
+
+	// Without a product name or quantity the item falls back to the SKU and one piece
+	second := orders[1]
+	if len(second.Errors) != 0 || len(second.Order.OrderDetails) != 1 {
+		t.Fatalf("order INV-002: errors %v, items %+v", second.Errors, second.Order.OrderDetails)
+	}
+	if detail := second.Order.OrderDetails[0]; detail.ProductName != "TAS" || detail.Quantity != 1 {
+		t.Errorf("order INV-002: item %+v", detail)
+	}
+
+	// Invalid fields are reported on the order instead of failing the file
+	third := orders[2]
+	for _, want := range []string{"row 7: invalid processing limit 'besok'", "row 7: invalid quantity '1.5'", "tracking is required", "order has no valid items"} {
+		if !containsString(third.Errors, want) {
+			t.Errorf("order INV-003: missing error '%s' in %v", want, third.Errors)
+		}
+	}
+}
+
+func TestParseGineeOrderExportReportsRowsWithoutOrder(t *testing.T) {
+	rows := [][]string{
+		{"Order ID", "SKU"},
+		{"", "KAOS-M"},
+		{"INV-001", ""},
+	}
+
+	orders, err := parseGineeOrderExport(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("expected 2 orders, got %d", len(orders))
+	}
+	if !containsString(orders[0].Errors, "row 2: order ID is required") {
+		t.Errorf("expected a missing order ID error, got %v", orders[0].Errors)
+	}
+	if !containsString(orders[1].Errors, "row 3: sku is required") {
+		t.Errorf("expected a missing sku error, got %v", orders[1].Errors)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
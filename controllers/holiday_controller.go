@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type HolidayController struct {
+	DB *gorm.DB
+}
+
+// NewHolidayController creates a new holiday controller
+func NewHolidayController(db *gorm.DB) *HolidayController {
+	return &HolidayController{DB: db}
+}
+
+// GetHolidays godoc
+// @Summary Get all holidays
+// @Description Get the holidays of the warehouse calendar ordered by date, with pagination and optional year filtering.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param year query int false "Filter by year"
+// @Success 200 {object} utils.Response{data=HolidaysListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /api/holidays [get]
+func (hc *HolidayController) GetHolidays(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var holidays []models.Holiday
+	var total int64
+
+	query := hc.DB.Model(&models.Holiday{})
+
+	if year := c.Query("year"); year != "" {
+		parsedYear, err := strconv.Atoi(year)
+		if err != nil || parsedYear < 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", "year must be a number, e.g. 2025")
+			return
+		}
+		query = query.Where("date >= ? AND date < ?", fmt.Sprintf("%04d-01-01", parsedYear), fmt.Sprintf("%04d-01-01", parsedYear+1))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count holidays", err.Error())
+		return
+	}
+
+	if err := query.Preload("Creator").Order("date ASC").Limit(limit).Offset(offset).Find(&holidays).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve holidays", err.Error())
+		return
+	}
+
+	response := HolidaysListResponse{
+		Holidays: make([]models.HolidayResponse, len(holidays)),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+	for i, holiday := range holidays {
+		response.Holidays[i] = holiday.ToHolidayResponse()
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays retrieved successfully", response)
+}
+
+// GetBusinessCalendar godoc
+// @Summary Get warehouse calendar
+// @Description Get the timezone, working days and working hours of the warehouse, and whether today is a working day. Date filters, daily codes, SLA calculations and reports all use this calendar.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=BusinessCalendarResponse}
+// @Failure 401 {object} utils.Response
+// @Router /api/holidays/calendar [get]
+func (hc *HolidayController) GetBusinessCalendar(c *gin.Context) {
+	calendar := utils.BusinessCalendar()
+	now := time.Now().In(calendar.Location)
+
+	response := BusinessCalendarResponse{
+		Timezone:     calendar.Location.String(),
+		WorkingDays:  []string{},
+		WorkingHours: fmt.Sprintf("%s-%s", formatClock(calendar.OpenAt), formatClock(calendar.CloseAt)),
+		Now:          now,
+		Today:        now.Format(utils.BusinessDateLayout),
+		IsWorkingDay: calendar.IsWorkingDay(now),
+		IsHoliday:    calendar.IsHoliday(now),
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if calendar.WorkingDays[day] {
+			response.WorkingDays = append(response.WorkingDays, day.String())
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Calendar retrieved successfully", response)
+}
+
+// CreateHoliday godoc
+// @Summary Create new holiday
+// @Description Add a day the warehouse does not work to the calendar. The day no longer counts as working time in SLA calculations.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param holiday body HolidayRequest true "Create holiday request"
+// @Success 201 {object} utils.Response{data=models.HolidayResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/holidays [post]
+func (hc *HolidayController) CreateHoliday(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	date, err := utils.ParseBusinessDate(req.Date)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	// Check for duplicate holiday date
+	var existingHoliday models.Holiday
+	if err := hc.DB.Where("date = ?", req.Date).First(&existingHoliday).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Holiday already exists", fmt.Sprintf("%s is already a holiday: %s", req.Date, existingHoliday.Name))
+		return
+	}
+
+	holiday := models.Holiday{
+		Date:      date,
+		Name:      req.Name,
+		CreatorID: creatorID,
+	}
+
+	if err := hc.DB.Create(&holiday).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create holiday", err.Error())
+		return
+	}
+
+	hc.refreshCalendar(c.Request.Context())
+
+	hc.DB.Preload("Creator").First(&holiday, holiday.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Holiday created successfully", holiday.ToHolidayResponse())
+}
+
+// UpdateHoliday godoc
+// @Summary Update holiday
+// @Description Update the date or name of a holiday.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Holiday ID"
+// @Param holiday body HolidayRequest true "Update holiday request"
+// @Success 200 {object} utils.Response{data=models.HolidayResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/holidays/{id} [put]
+func (hc *HolidayController) UpdateHoliday(c *gin.Context) {
+	holidayID := c.Param("id")
+
+	var req HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	date, err := utils.ParseBusinessDate(req.Date)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	var holiday models.Holiday
+	if err := hc.DB.First(&holiday, holidayID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Holiday not found", err.Error())
+		return
+	}
+
+	// Check for duplicate holiday date (excluding current holiday)
+	var existingHoliday models.Holiday
+	if err := hc.DB.Where("date = ? AND id <> ?", req.Date, holidayID).First(&existingHoliday).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Holiday already exists", fmt.Sprintf("%s is already a holiday: %s", req.Date, existingHoliday.Name))
+		return
+	}
+
+	holiday.Date = date
+	holiday.Name = req.Name
+
+	if err := hc.DB.Save(&holiday).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update holiday", err.Error())
+		return
+	}
+
+	hc.refreshCalendar(c.Request.Context())
+
+	hc.DB.Preload("Creator").First(&holiday, holiday.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Holiday updated successfully", holiday.ToHolidayResponse())
+}
+
+// RemoveHoliday godoc
+// @Summary Remove holiday
+// @Description Remove a holiday from the calendar. The day counts as working time again when it is a working day.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/holidays/{id} [delete]
+func (hc *HolidayController) RemoveHoliday(c *gin.Context) {
+	holidayID := c.Param("id")
+
+	var holiday models.Holiday
+	if err := hc.DB.First(&holiday, holidayID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Holiday not found", err.Error())
+		return
+	}
+
+	if err := hc.DB.Delete(&holiday).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete holiday", err.Error())
+		return
+	}
+
+	hc.refreshCalendar(c.Request.Context())
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday deleted successfully", nil)
+}
+
+// RefreshCalendar loads the holidays into the warehouse calendar. It runs periodically in the background so every
+// instance of the service picks up holidays changed on another one.
+func (hc *HolidayController) RefreshCalendar(ctx context.Context) error {
+	dates, err := models.LoadHolidayDates(hc.DB.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	utils.SetBusinessHolidays(dates)
+	return nil
+}
+
+// refreshCalendar refreshes the calendar after a holiday changed. The change is saved already, so a failure is only
+// logged; the periodic refresh catches up.
+func (hc *HolidayController) refreshCalendar(ctx context.Context) {
+	if err := hc.RefreshCalendar(ctx); err != nil {
+		log.Printf("⚠️  Failed to refresh holiday calendar: %v", err)
+	}
+}
+
+// formatClock formats a time of day, given from midnight, as HH:MM
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Request/Response structs
+type HolidaysListResponse struct {
+	Holidays   []models.HolidayResponse `json:"holidays"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
+
+type HolidayRequest struct {
+	Date string `json:"date" binding:"required" example:"2025-12-25"`
+	Name string `json:"name" binding:"required" example:"Hari Raya Natal"`
+}
+
+type BusinessCalendarResponse struct {
+	Timezone     string    `json:"timezone"`
+	WorkingDays  []string  `json:"working_days"`
+	WorkingHours string    `json:"working_hours"`
+	Now          time.Time `json:"now"`
+	Today        string    `json:"today"`
+	IsWorkingDay bool      `json:"is_working_day"`
+	IsHoliday    bool      `json:"is_holiday"`
+}
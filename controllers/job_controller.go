@@ -0,0 +1,332 @@
+package controllers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobController struct {
+	DB *gorm.DB
+}
+
+// NewJobController creates a new job controller
+func NewJobController(db *gorm.DB) *JobController {
+	return &JobController{DB: db}
+}
+
+// GetJobs godoc
+// @Summary Get background jobs
+// @Description Get the background jobs (imports and exports) of the current user, newest first, with pagination and optional type and status filtering. Superadmins see the jobs of every user.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param type query string false "Filter by job type (bulk_create_orders, import_ginee_orders, import_products, export_fee_charges, export_stock_opname, export_manifest, export_pick_list)"
+// @Param status query string false "Filter by status (queued, running, succeeded, failed, cancelled)"
+// @Success 200 {object} utils.Response{data=JobsListResponse}
+// @Failure 401 {object} utils.Response
+// @Router /api/jobs [get]
+func (jc *JobController) GetJobs(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var jobs []models.Job
+	var total int64
+
+	query := jc.DB.Model(&models.Job{})
+
+	if !isSuperadmin(c) {
+		query = query.Where("creator_id = ?", requesterID)
+	}
+
+	if jobType := c.Query("type"); jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count jobs", err.Error())
+		return
+	}
+
+	if err := query.Omit("file", "params").Preload("Creator").Order("id DESC").Limit(limit).Offset(offset).Find(&jobs).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve jobs", err.Error())
+		return
+	}
+
+	response := JobsListResponse{
+		Jobs: make([]models.JobResponse, len(jobs)),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+	for i, job := range jobs {
+		response.Jobs[i] = job.ToJobResponse()
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jobs retrieved successfully", response)
+}
+
+// GetJob godoc
+// @Summary Get background job
+// @Description Get the status, progress and result of a background job. Poll this endpoint until the status is succeeded, failed or cancelled.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} utils.Response{data=models.JobResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/jobs/{id} [get]
+func (jc *JobController) GetJob(c *gin.Context) {
+	job, ok := jc.findJob(c, jc.DB.Omit("file", "params").Preload("Creator"))
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Job retrieved successfully", job.ToJobResponse())
+}
+
+// DownloadJobFile godoc
+// @Summary Download background job file
+// @Description Download the file produced by a succeeded export job.
+// @Tags jobs
+// @Accept json
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {file} file
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/jobs/{id}/download [get]
+func (jc *JobController) DownloadJobFile(c *gin.Context) {
+	job, ok := jc.findJob(c, jc.DB)
+	if !ok {
+		return
+	}
+
+	if job.Status != models.JobStatusSucceeded {
+		utils.ErrorResponse(c, http.StatusConflict, "Job is not finished", fmt.Sprintf("job with status '%s' has no file to download", job.Status))
+		return
+	}
+	if job.FileName == "" {
+		utils.ErrorResponse(c, http.StatusNotFound, "File not found", "the job did not produce a file")
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": job.FileName}))
+	c.Data(http.StatusOK, job.ContentType, job.File)
+}
+
+// CancelJob godoc
+// @Summary Cancel background job
+// @Description Cancel a queued job, or request a running job to stop. A running job stops at its next checkpoint; orders that were already created by an import are kept.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} utils.Response{data=models.JobResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/jobs/{id}/cancel [put]
+func (jc *JobController) CancelJob(c *gin.Context) {
+	job, ok := jc.findJob(c, jc.DB.Omit("file", "params"))
+	if !ok {
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := jc.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the job so a worker cannot claim it while it is being cancelled
+		if err := tx.Omit("file", "params").Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, job.ID).Error; err != nil {
+			return err
+		}
+
+		switch job.Status {
+		case models.JobStatusQueued:
+			now := time.Now()
+			job.Status = models.JobStatusCancelled
+			job.FinishedAt = &now
+			return tx.Model(&job).Updates(map[string]interface{}{"status": job.Status, "finished_at": now}).Error
+		case models.JobStatusRunning:
+			job.CancelRequested = true
+			return tx.Model(&job).Update("cancel_requested", true).Error
+		default:
+			statusCode = http.StatusConflict
+			return fmt.Errorf("job with status '%s' cannot be cancelled", job.Status)
+		}
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to cancel job", err.Error())
+		return
+	}
+
+	message := "Job cancelled successfully"
+	if job.Status == models.JobStatusRunning {
+		message = "Job cancellation requested"
+	}
+	utils.SuccessResponse(c, http.StatusOK, message, job.ToJobResponse())
+}
+
+// RetryJob godoc
+// @Summary Retry background job
+// @Description Queue a failed or cancelled job again with the same parameters and a fresh set of attempts.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} utils.Response{data=models.JobResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/jobs/{id}/retry [put]
+func (jc *JobController) RetryJob(c *gin.Context) {
+	job, ok := jc.findJob(c, jc.DB.Omit("file", "params"))
+	if !ok {
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := jc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("file", "params").Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, job.ID).Error; err != nil {
+			return err
+		}
+
+		if job.Status != models.JobStatusFailed && job.Status != models.JobStatusCancelled {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("job with status '%s' cannot be retried", job.Status)
+		}
+
+		now := time.Now()
+		job.Status = models.JobStatusQueued
+		job.Attempts = 0
+		job.Progress = 0
+		job.Error = ""
+		job.CancelRequested = false
+		job.RunAt = now
+		job.StartedAt = nil
+		job.FinishedAt = nil
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":           job.Status,
+			"attempts":         0,
+			"progress":         0,
+			"error":            nil,
+			"result":           nil,
+			"cancel_requested": false,
+			"run_at":           now,
+			"started_at":       nil,
+			"finished_at":      nil,
+		}).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to retry job", err.Error())
+		return
+	}
+
+	job.Result = ""
+	utils.SuccessResponse(c, http.StatusOK, "Job queued for retry", job.ToJobResponse())
+}
+
+// findJob finds the job from the id path parameter with the given query and responds when it cannot.
+// Jobs of other users are only visible to superadmins.
+func (jc *JobController) findJob(c *gin.Context, query *gorm.DB) (models.Job, bool) {
+	var job models.Job
+
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return job, false
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return job, false
+	}
+
+	if err := query.First(&job, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Job not found", "no job found with the specified ID")
+			return job, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find job", err.Error())
+		return job, false
+	}
+
+	if job.CreatorID != requesterID && !isSuperadmin(c) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Job not found", "no job found with the specified ID")
+		return job, false
+	}
+
+	return job, true
+}
+
+// queueJob queues a background job and responds with it so the client can poll its status
+func queueJob(c *gin.Context, db *gorm.DB, jobType string, params interface{}, creatorID uint, message string) {
+	job, err := models.NewJob(jobType, params, creatorID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to queue job", err.Error())
+		return
+	}
+
+	if err := db.Create(job).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to queue job", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, message, job.ToJobResponse())
+}
+
+// isSuperadmin checks whether the current user has the superadmin role
+func isSuperadmin(c *gin.Context) bool {
+	roles, _ := c.Get("roles")
+	userRoles, _ := roles.([]string)
+	for _, role := range userRoles {
+		if role == "superadmin" {
+			return true
+		}
+	}
+	return false
+}
+
+// Request/Response structs
+type JobsListResponse struct {
+	Jobs       []models.JobResponse     `json:"jobs"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
@@ -0,0 +1,683 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ManifestController struct {
+	DB *gorm.DB
+}
+
+// NewManifestController creates a new manifest controller
+func NewManifestController(db *gorm.DB) *ManifestController {
+	return &ManifestController{DB: db}
+}
+
+// OpenManifest godoc
+// @Summary Open manifest
+// @Description Open a courier handover manifest for an expedition. Only one manifest per expedition can be open. The window starts at start_at, or by default where the previous manifest of the expedition ended (or at the oldest unmanifested outbound scan).
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body OpenManifestRequest true "Open manifest request"
+// @Success 201 {object} utils.Response{data=models.ManifestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/manifests [post]
+func (mc *ManifestController) OpenManifest(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	openerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req OpenManifestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.ExpeditionSlug = strings.TrimSpace(req.ExpeditionSlug)
+
+	var expedition models.Expedition
+	if err := mc.DB.Where("slug = ?", req.ExpeditionSlug).First(&expedition).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Expedition not found", fmt.Sprintf("no expedition found with slug '%s'", req.ExpeditionSlug))
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find expedition", err.Error())
+		return
+	}
+
+	windowStart := utils.BusinessNow()
+	if req.StartAt != nil {
+		windowStart = *req.StartAt
+	} else {
+		// Continue where the previous manifest ended, or cover parcels scanned before the first manifest
+		var previous models.Manifest
+		if err := mc.DB.Where("expedition_slug = ? AND status = ?", expedition.Slug, models.ManifestStatusClosed).
+			Order("window_end DESC").First(&previous).Error; err == nil && previous.WindowEnd != nil {
+			windowStart = *previous.WindowEnd
+		} else {
+			var oldest models.Outbound
+			if err := mc.DB.Where("expedition_slug = ? AND manifest_id IS NULL", expedition.Slug).
+				Order("created_at ASC").First(&oldest).Error; err == nil {
+				windowStart = oldest.CreatedAt
+			}
+		}
+	}
+
+	manifest := models.Manifest{
+		ExpeditionSlug:  expedition.Slug,
+		Expedition:      expedition.Name,
+		ExpeditionColor: expedition.Color,
+		Status:          models.ManifestStatusOpen,
+		WindowStart:     windowStart,
+		Notes:           strings.TrimSpace(req.Notes),
+		OpenerID:        openerID,
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := mc.DB.Transaction(func(tx *gorm.DB) error {
+		// Serialize opening manifests of the same expedition so only one of concurrent requests opens it
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "manifest_open:"+expedition.Slug).Error; err != nil {
+			return err
+		}
+
+		var openCount int64
+		if err := tx.Model(&models.Manifest{}).Where("expedition_slug = ? AND status = ?", expedition.Slug, models.ManifestStatusOpen).Count(&openCount).Error; err != nil {
+			return err
+		}
+		if openCount > 0 {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("expedition '%s' already has an open manifest", expedition.Name)
+		}
+
+		code, err := utils.GenerateManifestCode(tx)
+		if err != nil {
+			return err
+		}
+		manifest.Code = code
+		return tx.Create(&manifest).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to open manifest", err.Error())
+		return
+	}
+
+	mc.respondWithManifest(c, http.StatusCreated, "Manifest opened successfully", manifest.ID)
+}
+
+// GetManifests godoc
+// @Summary Get all manifests
+// @Description Get list of manifests with pagination, optional status, expedition and date range filtering. Parcels are not included; use the manifest detail endpoint.
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (open, closed)"
+// @Param expedition query string false "Filter by expedition slug"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=ManifestsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/manifests [get]
+func (mc *ManifestController) GetManifests(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	status := c.Query("status")
+	expedition := strings.TrimSpace(c.Query("expedition"))
+
+	var manifests []models.Manifest
+	var total int64
+
+	query := mc.DB.Model(&models.Manifest{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if expedition != "" {
+		query = query.Where("expedition_slug = ?", expedition)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count manifests", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("Opener").
+		Preload("Closer").
+		Find(&manifests).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve manifests", err.Error())
+		return
+	}
+
+	// Count parcels per manifest in one query
+	parcels := make(map[uint]int)
+	if len(manifests) > 0 {
+		manifestIDs := make([]uint, len(manifests))
+		for i, manifest := range manifests {
+			manifestIDs[i] = manifest.ID
+		}
+
+		var counts []struct {
+			ManifestID uint
+			Total      int
+		}
+		mc.DB.Model(&models.Outbound{}).
+			Select("manifest_id, COUNT(*) AS total").
+			Where("manifest_id IN ?", manifestIDs).
+			Group("manifest_id").
+			Scan(&counts)
+		for _, count := range counts {
+			parcels[count.ManifestID] = count.Total
+		}
+	}
+
+	manifestResponses := make([]models.ManifestResponse, len(manifests))
+	for i, manifest := range manifests {
+		if manifest.Status == models.ManifestStatusOpen {
+			var pending int64
+			manifest.PendingOutbounds(mc.DB).Count(&pending)
+			parcels[manifest.ID] = int(pending)
+		}
+
+		manifestResponses[i] = manifest.ToManifestResponse()
+		manifestResponses[i].TotalParcels = parcels[manifest.ID]
+	}
+
+	response := ManifestsListResponse{
+		Manifests: manifestResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Manifests retrieved successfully", response)
+}
+
+// GetManifestSummary godoc
+// @Summary Get manifest summary per expedition
+// @Description Get, for every expedition, the number of outbound parcels not handed over yet and the currently open manifest.
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]ManifestExpeditionSummary}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/manifests/summary [get]
+func (mc *ManifestController) GetManifestSummary(c *gin.Context) {
+	var expeditions []models.Expedition
+	if err := mc.DB.Order("name ASC").Find(&expeditions).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve expeditions", err.Error())
+		return
+	}
+
+	var counts []struct {
+		ExpeditionSlug string
+		Total          int
+	}
+	if err := mc.DB.Model(&models.Outbound{}).
+		Select("expedition_slug, COUNT(*) AS total").
+		Where("manifest_id IS NULL").
+		Group("expedition_slug").
+		Scan(&counts).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count outbounds", err.Error())
+		return
+	}
+	pending := make(map[string]int)
+	for _, count := range counts {
+		pending[count.ExpeditionSlug] = count.Total
+	}
+
+	var openManifests []models.Manifest
+	mc.DB.Where("status = ?", models.ManifestStatusOpen).Find(&openManifests)
+	open := make(map[string]models.Manifest)
+	for _, manifest := range openManifests {
+		open[manifest.ExpeditionSlug] = manifest
+	}
+
+	// Several tracking prefixes share one expedition slug, so list each slug once
+	summary := []ManifestExpeditionSummary{}
+	seen := make(map[string]bool)
+	for _, expedition := range expeditions {
+		if seen[expedition.Slug] {
+			continue
+		}
+		seen[expedition.Slug] = true
+
+		line := ManifestExpeditionSummary{
+			ExpeditionSlug:  expedition.Slug,
+			Expedition:      expedition.Name,
+			ExpeditionColor: expedition.Color,
+			PendingParcels:  pending[expedition.Slug],
+		}
+		if manifest, exists := open[expedition.Slug]; exists {
+			manifestID := manifest.ID
+			line.OpenManifestID = &manifestID
+			line.OpenManifestCode = manifest.Code
+		}
+		summary = append(summary, line)
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Manifest summary retrieved successfully", summary)
+}
+
+// GetManifest godoc
+// @Summary Get manifest by ID
+// @Description Get a manifest with its parcels and parcel counts per operator. For an open manifest the parcels currently in its window are listed.
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Manifest ID"
+// @Success 200 {object} utils.Response{data=models.ManifestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/manifests/{id} [get]
+func (mc *ManifestController) GetManifest(c *gin.Context) {
+	manifestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid manifest ID", "Manifest ID must be a valid number")
+		return
+	}
+
+	mc.respondWithManifest(c, http.StatusOK, "Manifest retrieved successfully", uint(manifestID))
+}
+
+// CloseManifest godoc
+// @Summary Close manifest
+// @Description Close an open manifest when the courier driver picks up. The parcels in the window are attached to the manifest and their orders move to "shipped".
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Manifest ID"
+// @Param request body CloseManifestRequest true "Close manifest request"
+// @Success 200 {object} utils.Response{data=models.ManifestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/manifests/{id}/close [put]
+func (mc *ManifestController) CloseManifest(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	closerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CloseManifestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var manifest models.Manifest
+	statusCode := http.StatusInternalServerError
+	if err := mc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&manifest, c.Param("id")).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no manifest found with the specified ID")
+			}
+			return err
+		}
+
+		if manifest.Status != models.ManifestStatusOpen {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("manifest status is '%s'. Only open manifests can be closed", manifest.Status)
+		}
+
+		now := time.Now()
+
+		var outbounds []models.Outbound
+		if err := manifest.PendingOutbounds(tx).Where("created_at <= ?", now).Find(&outbounds).Error; err != nil {
+			return err
+		}
+		if len(outbounds) == 0 {
+			statusCode = http.StatusUnprocessableEntity
+			return fmt.Errorf("manifest has no parcels to hand over")
+		}
+
+		outboundIDs := make([]uint, len(outbounds))
+		trackings := make([]string, len(outbounds))
+		for i, ob := range outbounds {
+			outboundIDs[i] = ob.ID
+			trackings[i] = ob.Tracking
+		}
+
+		if err := tx.Model(&models.Outbound{}).Where("id IN ?", outboundIDs).Update("manifest_id", manifest.ID).Error; err != nil {
+			return err
+		}
+
+		// Parcels handed over to the courier are shipped
+		var orders []models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tracking IN ? AND status = ?", trackings, models.OrderStatusOutbound).
+			Find(&orders).Error; err != nil {
+			return err
+		}
+		events := make([]*models.OrderEvent, len(orders))
+		for i := range orders {
+			if err := orders[i].TransitionTo(models.OrderStatusShipped, &closerID); err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Save(&orders[i]).Error; err != nil {
+				return err
+			}
+			events[i] = models.NewOrderStatusEvent(&orders[i], models.OrderStatusOutbound, &closerID, "Handed over in manifest "+manifest.Code)
+		}
+		if err := models.RecordOrderEvents(tx, events...); err != nil {
+			return err
+		}
+
+		manifest.Status = models.ManifestStatusClosed
+		manifest.WindowEnd = &now
+		manifest.CloserID = &closerID
+		manifest.DriverName = strings.TrimSpace(req.DriverName)
+		if notes := strings.TrimSpace(req.Notes); notes != "" {
+			manifest.Notes = notes
+		}
+		return tx.Omit(clause.Associations).Save(&manifest).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to close manifest", err.Error())
+		return
+	}
+
+	mc.respondWithManifest(c, http.StatusOK, "Manifest closed successfully", manifest.ID)
+}
+
+// ExportManifest godoc
+// @Summary Export manifest
+// @Description Download a printable handover document of a manifest with a signature block for the courier driver. Spreadsheet exports are queued with POST /api/manifests/{id}/export.
+// @Tags manifests
+// @Accept json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Manifest ID"
+// @Param format query string false "Export format (pdf)" default(pdf)
+// @Success 200 {file} file
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/manifests/{id}/export [get]
+func (mc *ManifestController) ExportManifest(c *gin.Context) {
+	manifestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid manifest ID", "Manifest ID must be a valid number")
+		return
+	}
+
+	if format := c.DefaultQuery("format", "pdf"); format != "pdf" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid format", "format must be 'pdf', queue csv exports with POST /api/manifests/{id}/export")
+		return
+	}
+
+	manifest, err := mc.loadManifest(uint(manifestID))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Manifest not found", "no manifest found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve manifest", err.Error())
+		return
+	}
+
+	windowEnd := "-"
+	if manifest.WindowEnd != nil {
+		windowEnd = manifest.WindowEnd.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
+	}
+
+	lines := []string{
+		"COURIER HANDOVER MANIFEST",
+		"",
+		fmt.Sprintf("Manifest   : %s (%s)", manifest.Code, manifest.Status),
+		fmt.Sprintf("Expedition : %s", manifest.Expedition),
+		fmt.Sprintf("Window     : %s - %s", manifest.WindowStart.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05"), windowEnd),
+		fmt.Sprintf("Driver     : %s", manifest.DriverName),
+		fmt.Sprintf("Parcels    : %d", len(manifest.Outbounds)),
+		"",
+		fmt.Sprintf("%-5s %-30s %-20s %s", "No", "Tracking", "Scanned At", "Operator"),
+		strings.Repeat("-", 90),
+	}
+	for i, ob := range manifest.Outbounds {
+		lines = append(lines, fmt.Sprintf("%-5d %-30s %-20s %s", i+1, ob.Tracking, ob.CreatedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05"), outboundOperatorName(ob)))
+	}
+
+	lines = append(lines, strings.Repeat("-", 90), "", "Parcels per operator:")
+	for _, operator := range manifest.OperatorCounts() {
+		lines = append(lines, fmt.Sprintf("  %-40s %d", operator.Name, operator.Parcels))
+	}
+
+	lines = append(lines,
+		"",
+		"",
+		fmt.Sprintf("%-45s %s", "Handed over by,", "Received by (driver),"),
+		"",
+		"",
+		"",
+		fmt.Sprintf("%-45s %s", "(______________________)", "(______________________)"),
+	)
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=manifest-%s.pdf", manifest.Code))
+	if err := utils.WriteTextPDF(c.Writer, lines); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export manifest", err.Error())
+	}
+}
+
+// QueueManifestExport godoc
+// @Summary Queue manifest csv export
+// @Description Queue a background job that exports the parcels of a manifest as CSV. Poll GET /api/jobs/{id} and download the file from GET /api/jobs/{id}/download.
+// @Tags manifests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Manifest ID"
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/manifests/{id}/export [post]
+func (mc *ManifestController) QueueManifestExport(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	manifestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid manifest ID", "Manifest ID must be a valid number")
+		return
+	}
+
+	var manifest models.Manifest
+	if err := mc.DB.First(&manifest, manifestID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Manifest not found", "no manifest found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve manifest", err.Error())
+		return
+	}
+
+	queueJob(c, mc.DB, models.JobTypeExportManifest, ManifestExportJobParams{ManifestID: manifest.ID}, requesterID, "Manifest export queued")
+}
+
+// RunExportManifestJob writes the parcels of a queued manifest export as the CSV file of the job
+func (mc *ManifestController) RunExportManifestJob(ctx context.Context, task *jobs.Task) error {
+	var params ManifestExportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	manifest, err := mc.loadManifest(params.ManifestID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return jobs.Permanent(fmt.Errorf("manifest %d not found", params.ManifestID))
+		}
+		return err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"No", "Tracking", "Expedition", "Scanned At", "Operator"})
+	task.SetTotal(len(manifest.Outbounds))
+	for i, ob := range manifest.Outbounds {
+		writer.Write([]string{
+			strconv.Itoa(i + 1),
+			ob.Tracking,
+			ob.Expedition,
+			ob.CreatedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05"),
+			outboundOperatorName(ob),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	task.SetProgress(len(manifest.Outbounds))
+	task.SetFile(fmt.Sprintf("manifest-%s.csv", manifest.Code), "text/csv", buffer.Bytes())
+	return nil
+}
+
+// outboundOperatorName returns the name of the operator who scanned the parcel, or the ID when the operator is gone
+func outboundOperatorName(ob models.Outbound) string {
+	if ob.User != nil {
+		return ob.User.Name
+	}
+	return strconv.FormatUint(uint64(ob.UserID), 10)
+}
+
+// loadManifest loads a manifest with its parcels. Open manifests get the parcels currently in their window.
+func (mc *ManifestController) loadManifest(manifestID uint) (*models.Manifest, error) {
+	var manifest models.Manifest
+	if err := mc.DB.
+		Preload("Opener").
+		Preload("Closer").
+		Preload("Outbounds", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Outbounds.User").
+		First(&manifest, manifestID).Error; err != nil {
+		return nil, err
+	}
+
+	if manifest.Status == models.ManifestStatusOpen {
+		if err := manifest.PendingOutbounds(mc.DB).Order("created_at ASC").Preload("User").Find(&manifest.Outbounds).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &manifest, nil
+}
+
+// respondWithManifest loads the manifest and responds with its parcels
+func (mc *ManifestController) respondWithManifest(c *gin.Context, statusCode int, message string, manifestID uint) {
+	manifest, err := mc.loadManifest(manifestID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Manifest not found", "no manifest found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve manifest", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, statusCode, message, manifest.ToManifestResponse())
+}
+
+// Request/Response structs
+type ManifestExportJobParams struct {
+	ManifestID uint `json:"manifest_id"`
+}
+
+type OpenManifestRequest struct {
+	ExpeditionSlug string     `json:"expedition_slug" binding:"required"`
+	StartAt        *time.Time `json:"start_at"`
+	Notes          string     `json:"notes"`
+}
+
+type CloseManifestRequest struct {
+	DriverName string `json:"driver_name" binding:"required"`
+	Notes      string `json:"notes"`
+}
+
+type ManifestExpeditionSummary struct {
+	ExpeditionSlug   string `json:"expedition_slug"`
+	Expedition       string `json:"expedition"`
+	ExpeditionColor  string `json:"expedition_color"`
+	PendingParcels   int    `json:"pending_parcels"`
+	OpenManifestID   *uint  `json:"open_manifest_id"`
+	OpenManifestCode string `json:"open_manifest_code"`
+}
+
+type ManifestsListResponse struct {
+	Manifests  []models.ManifestResponse `json:"manifests"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
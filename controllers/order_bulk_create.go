@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+
+	"gorm.io/gorm"
+)
+
+// Bulk order creation looks up existing orders and inserts new orders this many at a time
+const (
+	bulkOrderLookupSize = 1000
+	bulkOrderBatchSize  = 500
+)
+
+// createOrdersInBulk creates the orders as Ginee orders, skipping order ginee IDs that already exist.
+// Existing order ginee IDs and trackings are looked up upfront, then the orders are inserted in batches, each batch
+// in its own transaction. When a batch fails (e.g. an order was created concurrently) its orders are created one by one
+// so only the conflicting orders fail. With allOrNothing nothing is created when any order fails and all batches are
+// inserted in a single transaction; the returned error is the reason the transaction was rolled back.
+// Skipped and failed orders are reported with their index in orders. Progress is reported after each batch with the
+// number of orders handled. When ctx is done between batches the report of the orders created so far is returned
+// with the context error. The created events of the orders carry the note.
+func (oc *OrderController) createOrdersInBulk(ctx context.Context, importerID uint, orders []CreateOrderRequest, allOrNothing bool, note string, progress func(done int)) (BulkCreateOrderResponse, error) {
+	var createdOrders []models.Order
+	var skippedOrders []SkippedOrder
+	var failedOrders []FailedOrder
+
+	pending, indexes, err := oc.prepareBulkOrders(importerID, orders, &skippedOrders, &failedOrders)
+	if err != nil {
+		return BulkCreateOrderResponse{}, err
+	}
+
+	// Orders that are not created are handled once they are reported
+	handled := len(orders) - len(pending)
+	progress(handled)
+
+	switch {
+	case allOrNothing && len(failedOrders) > 0:
+		// Nothing is created
+	case allOrNothing:
+		if err := oc.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for start := 0; start < len(pending); start += bulkOrderBatchSize {
+				end := min(start+bulkOrderBatchSize, len(pending))
+				if err := createOrderBatch(tx, pending[start:end], note); err != nil {
+					return err
+				}
+				progress(handled + end)
+			}
+			return nil
+		}); err != nil {
+			return BulkCreateOrderResponse{}, err
+		}
+		createdOrders = pending
+	default:
+		for start := 0; start < len(pending); start += bulkOrderBatchSize {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+
+			end := min(start+bulkOrderBatchSize, len(pending))
+			if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+				return createOrderBatch(tx, pending[start:end], note)
+			}); err == nil {
+				progress(handled + end)
+				createdOrders = append(createdOrders, pending[start:end]...)
+				continue
+			}
+
+			// Retry the orders of the failed batch one by one, rebuilt since the rolled back batch left IDs behind
+			for i := start; i < end; i++ {
+				index := indexes[i]
+				order, _ := newGineeOrder(orders[index], &importerID)
+				if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+					return createOrderWithDetails(tx, &order, models.OrderStationImport, note)
+				}); err != nil {
+					failedOrders = append(failedOrders, FailedOrder{
+						Index:        index,
+						OrderGineeID: orders[index].OrderGineeID,
+						Error:        err.Error(),
+					})
+					continue
+				}
+				createdOrders = append(createdOrders, order)
+			}
+			progress(handled + end)
+		}
+	}
+
+	// Convert created orders to response format
+	createdOrderResponses := make([]models.OrderResponse, len(createdOrders))
+	for i, order := range createdOrders {
+		createdOrderResponses[i] = order.ToOrderResponse()
+	}
+
+	return BulkCreateOrderResponse{
+		Summary: BulkCreateSummary{
+			Total:        len(orders),
+			Created:      len(createdOrders),
+			Skipped:      len(skippedOrders),
+			Failed:       len(failedOrders),
+			AllOrNothing: allOrNothing,
+		},
+		CreatedOrders: createdOrderResponses,
+		SkippedOrders: skippedOrders,
+		FailedOrders:  failedOrders,
+	}, err
+}
+
+// prepareBulkOrders builds the orders to create and reports the orders that are skipped or would fail.
+// It returns the orders to create with their index in orders.
+func (oc *OrderController) prepareBulkOrders(importerID uint, orders []CreateOrderRequest, skippedOrders *[]SkippedOrder, failedOrders *[]FailedOrder) ([]models.Order, []int, error) {
+	// Removed orders are included since they keep their order ginee ID and tracking
+	existingOrders := make(map[string]models.Order)
+	existingTrackings := make(map[string]string)
+	for start := 0; start < len(orders); start += bulkOrderLookupSize {
+		chunk := orders[start:min(start+bulkOrderLookupSize, len(orders))]
+		orderGineeIDs := make([]string, len(chunk))
+		trackings := make([]string, len(chunk))
+		for i, orderReq := range chunk {
+			orderGineeIDs[i] = orderReq.OrderGineeID
+			trackings[i] = orderReq.Tracking
+		}
+
+		var found []models.Order
+		if err := oc.DB.Unscoped().Select("id", "order_ginee_id", "tracking", "deleted_at").
+			Where("order_ginee_id IN ? OR tracking IN ?", orderGineeIDs, trackings).
+			Find(&found).Error; err != nil {
+			return nil, nil, err
+		}
+		for _, order := range found {
+			existingOrders[order.OrderGineeID] = order
+			existingTrackings[order.Tracking] = order.OrderGineeID
+		}
+	}
+
+	var pending []models.Order
+	var indexes []int
+	requested := make(map[string]int)
+	requestedTrackings := make(map[string]string)
+
+	for i, orderReq := range orders {
+		// Check if order with same OrderGineeID already exists
+		if existing, exists := existingOrders[orderReq.OrderGineeID]; exists {
+			reason := "Order already exists"
+			if existing.DeletedAt.Valid {
+				reason = "Order already exists (deleted)"
+			}
+			*skippedOrders = append(*skippedOrders, SkippedOrder{
+				Index:        i,
+				OrderGineeID: orderReq.OrderGineeID,
+				Reason:       reason,
+			})
+			continue
+		}
+		if first, exists := requested[orderReq.OrderGineeID]; exists {
+			*skippedOrders = append(*skippedOrders, SkippedOrder{
+				Index:        i,
+				OrderGineeID: orderReq.OrderGineeID,
+				Reason:       fmt.Sprintf("Duplicate of order at index %d", first),
+			})
+			continue
+		}
+
+		order, err := newGineeOrder(orderReq, &importerID)
+		if err == nil {
+			if orderGineeID, exists := existingTrackings[orderReq.Tracking]; exists {
+				err = fmt.Errorf("Tracking '%s' is already used by order '%s'", orderReq.Tracking, orderGineeID)
+			} else if orderGineeID, exists := requestedTrackings[orderReq.Tracking]; exists {
+				err = fmt.Errorf("Tracking '%s' is already used by order '%s' in this request", orderReq.Tracking, orderGineeID)
+			}
+		}
+		if err != nil {
+			*failedOrders = append(*failedOrders, FailedOrder{
+				Index:        i,
+				OrderGineeID: orderReq.OrderGineeID,
+				Error:        err.Error(),
+			})
+			continue
+		}
+
+		requested[orderReq.OrderGineeID] = i
+		requestedTrackings[orderReq.Tracking] = orderReq.OrderGineeID
+		pending = append(pending, order)
+		indexes = append(indexes, i)
+	}
+
+	return pending, indexes, nil
+}
+
+// createOrderBatch inserts the orders with their details in batches, explodes their bundle SKUs and records their
+// created events with the note
+func createOrderBatch(tx *gorm.DB, orders []models.Order, note string) error {
+	batch := tx.Session(&gorm.Session{CreateBatchSize: bulkOrderBatchSize})
+	if err := batch.Create(&orders).Error; err != nil {
+		return err
+	}
+
+	created := make([]*models.Order, len(orders))
+	for i := range orders {
+		created[i] = &orders[i]
+	}
+	if err := models.ExplodeBundles(tx, created...); err != nil {
+		return err
+	}
+
+	events := make([]*models.OrderEvent, len(orders))
+	for i := range orders {
+		event, err := models.NewOrderCreatedEvent(&orders[i], models.OrderStationImport, note)
+		if err != nil {
+			return err
+		}
+		events[i] = event
+	}
+	return models.RecordOrderEvents(batch, events...)
+}
+
+// RunBulkCreateOrdersJob creates the orders of a queued bulk creation. The report is the result of the job.
+func (oc *OrderController) RunBulkCreateOrdersJob(ctx context.Context, task *jobs.Task) error {
+	var req BulkCreateOrderRequest
+	if err := task.DecodeParams(&req); err != nil {
+		return err
+	}
+
+	task.SetTotal(len(req.Orders))
+	response, err := oc.createOrdersInBulk(ctx, task.Job.CreatorID, req.Orders, req.AllOrNothing, "Created by bulk order creation", task.SetProgress)
+	if response.Summary.Total > 0 {
+		if resultErr := task.SetResult(response); resultErr != nil {
+			return resultErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return bulkCreateOutcome(response)
+}
+
+// RunImportGineeOrdersJob creates the orders of a queued Ginee export import. The report, with the indexes of the
+// parsed file, is the result of the job.
+func (oc *OrderController) RunImportGineeOrdersJob(ctx context.Context, task *jobs.Task) error {
+	var params GineeImportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	task.SetTotal(params.Total)
+
+	var response BulkCreateOrderResponse
+	var err error
+	if params.AllOrNothing && len(params.InvalidOrders) > 0 {
+		// Nothing is created when any order of the file is invalid
+		response.CreatedOrders = []models.OrderResponse{}
+		response.Summary.AllOrNothing = true
+	} else {
+		// Invalid orders are handled upfront
+		response, err = oc.createOrdersInBulk(ctx, task.Job.CreatorID, params.Orders, params.AllOrNothing, "Imported from Ginee export", func(done int) {
+			task.SetProgress(len(params.InvalidOrders) + done)
+		})
+		if err != nil && response.Summary.Total == 0 {
+			return err
+		}
+	}
+	for i := range response.SkippedOrders {
+		response.SkippedOrders[i].Index = params.Indexes[response.SkippedOrders[i].Index]
+	}
+	for i := range response.FailedOrders {
+		response.FailedOrders[i].Index = params.Indexes[response.FailedOrders[i].Index]
+	}
+	response.FailedOrders = append(response.FailedOrders, params.InvalidOrders...)
+	response.Summary.Total = params.Total
+	response.Summary.Skipped = len(response.SkippedOrders)
+	response.Summary.Failed = len(response.FailedOrders)
+
+	if resultErr := task.SetResult(response); resultErr != nil {
+		return resultErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return bulkCreateOutcome(response)
+}
+
+// bulkCreateOutcome fails the job permanently when the report shows that no order could be created
+func bulkCreateOutcome(response BulkCreateOrderResponse) error {
+	if response.Summary.AllOrNothing && response.Summary.Failed > 0 {
+		return jobs.Permanent(errors.New("No orders were created because some orders failed"))
+	}
+	if response.Summary.Created == 0 && response.Summary.Skipped == 0 && response.Summary.Failed > 0 {
+		return jobs.Permanent(errors.New("No orders could be created"))
+	}
+	return nil
+}
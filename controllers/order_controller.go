@@ -1,17 +1,18 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"livo-backend-2.0/models"
 	"livo-backend-2.0/utils"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderController struct {
@@ -41,6 +42,19 @@ func NewOrderController(db *gorm.DB) *OrderController {
 func (oc *OrderController) UpdateOrderComplainedStatus(c *gin.Context) {
 	orderID := c.Param("id")
 
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	actorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
 	var req UpdateComplainedStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ValidationErrorResponse(c, err)
@@ -58,12 +72,25 @@ func (oc *OrderController) UpdateOrderComplainedStatus(c *gin.Context) {
 		return
 	}
 
-	// Update complained status
-	order.Complained = req.Complained
+	// Update complained status, recording the change in the order history
+	if order.Complained != req.Complained {
+		event, err := models.NewOrderEvent(order.ID, models.OrderEventComplainedChanged, models.OrderStationAdmin,
+			gin.H{"complained": order.Complained}, gin.H{"complained": req.Complained}, &actorID, "")
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update order complained status", err.Error())
+			return
+		}
+		order.Complained = req.Complained
 
-	if err := oc.DB.Save(&order).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update order complained status", err.Error())
-		return
+		if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&order).Error; err != nil {
+				return err
+			}
+			return models.RecordOrderEvents(tx, event)
+		}); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update order complained status", err.Error())
+			return
+		}
 	}
 
 	// Load order with details for response
@@ -84,6 +111,374 @@ type UpdateComplainedStatusRequest struct {
 	Complained bool `json:"complained" binding:"required" example:"true"`
 }
 
+// GetOrderStatusTransitions godoc
+// @Summary Get order status transitions
+// @Description Get the current status of an order and the statuses it can move to next.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=OrderStatusTransitionsResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/orders/{id}/status [get]
+func (oc *OrderController) GetOrderStatusTransitions(c *gin.Context) {
+	orderID := c.Param("id")
+
+	var order models.Order
+	if err := oc.DB.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Order not found", "no order found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find order", err.Error())
+		return
+	}
+
+	response := OrderStatusTransitionsResponse{
+		ID:                  order.ID,
+		Status:              order.Status,
+		AllowedNextStatuses: order.AllowedNextStatuses(),
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Order status transitions retrieved successfully", response)
+}
+
+// UpdateOrderStatus godoc
+// @Summary Update order status
+// @Description Send an order back to "ready to pick" by hand to correct a stuck claim or a wrong pick. A claim in progress is discarded, a picked order keeps its pick order as history and its picked items are booked back into their bin. Statuses set by the pick, QC, outbound, manifest, return and cancel endpoints are rejected with the endpoint to use, so their records and stock movements are never skipped. Illegal transitions are rejected with the list of allowed next statuses.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body UpdateOrderStatusRequest true "Update order status request"
+// @Success 200 {object} utils.Response{data=models.OrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/orders/{id}/status [put]
+func (oc *OrderController) UpdateOrderStatus(c *gin.Context) {
+	orderID := c.Param("id")
+
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	actorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	if !models.IsValidOrderStatus(req.Status) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid order status", fmt.Sprintf("unknown order status '%s'", req.Status))
+		return
+	}
+
+	// Stations book their records and stock movements along with the status, so only they may set it
+	if endpoint := models.OrderStatusStationEndpoint(req.Status); endpoint != "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Use the station endpoint", fmt.Sprintf("orders move to '%s' through %s", req.Status, endpoint))
+		return
+	}
+
+	var order models.Order
+	statusCode := http.StatusInternalServerError
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so a station cannot move it in between
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no order found with the specified ID")
+			}
+			return err
+		}
+
+		// Apply the transition, this validates the lifecycle and stamps actor columns
+		previousStatus := order.Status
+		if err := order.TransitionTo(req.Status, &actorID); err != nil {
+			statusCode = http.StatusConflict
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(&order).Error; err != nil {
+			return err
+		}
+		if err := models.RecordOrderEvents(tx, models.NewOrderStatusEvent(&order, previousStatus, &actorID, "Status updated manually")); err != nil {
+			return err
+		}
+
+		switch previousStatus {
+		case models.OrderStatusPicking:
+			// A stuck claim is discarded like a release by the picker, earlier pick orders are kept as history
+			var pickOrder models.PickOrder
+			if err := tx.Where("order_id = ?", order.ID).Order("id DESC").First(&pickOrder).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return nil
+				}
+				return err
+			}
+			if err := tx.Where("pick_order_id = ?", pickOrder.ID).Delete(&models.PickOrderDetail{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&pickOrder).Error
+		case models.OrderStatusPicked:
+			// The pick order is kept as history, the picked items go back to their bin for the repick
+			if _, err := restockPickedItems(tx, &order, &actorID, "Order sent back to pick"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to update order status", err.Error())
+		return
+	}
+
+	// Load order with details for response
+	oc.DB.Preload("OrderDetails").
+		Preload("Picker").
+		Preload("Importer").
+		Preload("Updater").
+		Preload("Canceler").
+		First(&order, order.ID)
+
+	utils.SuccessResponse(c, http.StatusOK, "Order status updated to '"+order.Status+"'", order.ToOrderResponse())
+}
+
+// CancelOrder godoc
+// @Summary Cancel order
+// @Description Cancel an order with a mandatory reason. Pick and QC records are kept as history, picked items are booked back into their bin, and any outbound scan is removed so the parcel is not handed over to the courier. Parcels already on a manifest cannot be cancelled.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body CancelOrderRequest true "Cancel order request"
+// @Success 200 {object} utils.Response{data=CancelOrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/orders/{id}/cancel [put]
+func (oc *OrderController) CancelOrder(c *gin.Context) {
+	orderID := c.Param("id")
+
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	cancelerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CancelOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Cancel reason is required", "reason must not be empty")
+		return
+	}
+
+	var order models.Order
+	var result CancelOrderResponse
+	statusCode := http.StatusInternalServerError
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		// The order is locked so a station cannot move it while it is cancelled
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no order found with the specified ID")
+			}
+			return err
+		}
+
+		if !order.CanTransitionTo(models.OrderStatusCancelled) {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order with status '%s' cannot be cancelled", order.Status)
+		}
+
+		var err error
+		result, err = cancelOrder(tx, &order, &cancelerID, req.Reason, models.OrderStationAdmin)
+		if err == errOrderInManifest {
+			statusCode = http.StatusConflict
+		}
+		return err
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to cancel order", err.Error())
+		return
+	}
+
+	// Load order with details for response
+	oc.DB.Preload("OrderDetails").
+		Preload("Picker").
+		Preload("Importer").
+		Preload("Updater").
+		Preload("Canceler").
+		First(&order, order.ID)
+	result.Order = order.ToOrderResponse()
+
+	utils.SuccessResponse(c, http.StatusOK, "Order cancelled successfully", result)
+}
+
+// errOrderInManifest is returned by cancelOrder when the parcel is already on a manifest
+var errOrderInManifest = errors.New("the parcel is already on a manifest handed over to the courier")
+
+// cancelOrder cancels the order inside the given transaction and applies the station rules:
+// pick and QC records are kept as history, picked items are booked back into their bin and outbound scans are
+// removed from the handover flow. The order must be locked by the caller. Parcels on a manifest are refused with
+// errOrderInManifest. The cancellation is recorded in the order history at the given station with the reason.
+func cancelOrder(tx *gorm.DB, order *models.Order, cancelerID *uint, reason string, station string) (CancelOrderResponse, error) {
+	var result CancelOrderResponse
+	previousStatus := order.Status
+
+	var outbounds []models.Outbound
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tracking = ?", order.Tracking).Find(&outbounds).Error; err != nil {
+		return result, err
+	}
+	for _, outbound := range outbounds {
+		if outbound.ManifestID != nil {
+			return result, errOrderInManifest
+		}
+	}
+
+	if err := order.TransitionTo(models.OrderStatusCancelled, cancelerID); err != nil {
+		return result, err
+	}
+	order.CancelReason = reason
+
+	if err := tx.Save(order).Error; err != nil {
+		return result, err
+	}
+
+	event := models.NewOrderStatusEvent(order, previousStatus, cancelerID, reason)
+	event.Station = station
+	if err := models.RecordOrderEvents(tx, event); err != nil {
+		return result, err
+	}
+
+	// Pick records are kept as history, the picked items go back to the bin they were picked from
+	var pickOrders int64
+	if err := tx.Model(&models.PickOrder{}).Where("order_id = ?", order.ID).Count(&pickOrders).Error; err != nil {
+		return result, err
+	}
+	restocked, err := restockPickedItems(tx, order, cancelerID, "Order cancelled")
+	if err != nil {
+		return result, err
+	}
+
+	// QC records are kept since they record the boxes the parcel was packed in
+	var qcOnline, qcRibbon int64
+	if err := tx.Model(&models.QcOnline{}).Where("tracking = ?", order.Tracking).Count(&qcOnline).Error; err != nil {
+		return result, err
+	}
+	if err := tx.Model(&models.QcRibbon{}).Where("tracking = ?", order.Tracking).Count(&qcRibbon).Error; err != nil {
+		return result, err
+	}
+
+	// Outbound scans are removed so the parcel is not handed over to the courier
+	outbound := tx.Where("tracking = ?", order.Tracking).Delete(&models.Outbound{})
+	if outbound.Error != nil {
+		return result, outbound.Error
+	}
+
+	result.PreviousStatus = previousStatus
+	result.PickOrdersRetained = int(pickOrders)
+	result.ItemsRestocked = restocked
+	result.QcRecordsRetained = int(qcOnline + qcRibbon)
+	result.OutboundRemoved = outbound.RowsAffected > 0
+
+	return result, nil
+}
+
+// restockPickedItems reverses the pick movements of the order that were not booked back yet with cancel restock
+// movements and returns the number of items booked back into stock
+func restockPickedItems(tx *gorm.DB, order *models.Order, userID *uint, note string) (int, error) {
+	if order.Tracking == "" {
+		return 0, nil
+	}
+
+	var picked []struct {
+		Sku      string
+		Location string
+		Quantity int
+	}
+	if err := tx.Model(&models.StockMovement{}).
+		Select("sku, location, SUM(quantity) AS quantity").
+		Where("type IN ? AND reference = ?", []string{models.StockMovementPick, models.StockMovementCancelRestock}, order.Tracking).
+		Group("sku, location").
+		Order("sku, location").
+		Scan(&picked).Error; err != nil {
+		return 0, err
+	}
+
+	restocked := 0
+	for _, line := range picked {
+		// Pick movements are negative, lines already booked back net out to zero
+		if line.Quantity >= 0 {
+			continue
+		}
+		if err := models.RecordStockMovement(tx, &models.StockMovement{
+			Sku:       line.Sku,
+			Location:  line.Location,
+			Type:      models.StockMovementCancelRestock,
+			Quantity:  -line.Quantity,
+			Reference: order.Tracking,
+			Note:      note,
+			UserID:    userID,
+		}); err != nil {
+			return 0, err
+		}
+		restocked -= line.Quantity
+	}
+	return restocked, nil
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Buyer requested cancellation"`
+}
+
+type CancelOrderResponse struct {
+	Order              models.OrderResponse `json:"order"`
+	PreviousStatus     string               `json:"previous_status"`
+	PickOrdersRetained int                  `json:"pick_orders_retained"`
+	QcRecordsRetained  int                  `json:"qc_records_retained"`
+	ItemsRestocked     int                  `json:"items_restocked"`
+	OutboundRemoved    bool                 `json:"outbound_removed"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required" example:"ready to pick"`
+}
+
+type OrderStatusTransitionsResponse struct {
+	ID                  uint     `json:"id"`
+	Status              string   `json:"status"`
+	AllowedNextStatuses []string `json:"allowed_next_statuses"`
+}
+
 // GetOrders godoc
 // @Summary Get all orders
 // @Description Get list of all orders with optional date range filtering and search.
@@ -123,23 +518,22 @@ func (oc *OrderController) GetOrders(c *gin.Context) {
 	// Apply date range filters if provided
 	if startDate != "" {
 		// Parse start date and set time to beginning of day
-		if parsedStartDate, err := time.Parse("2006-01-02", startDate); err != nil {
+		if parsedStartDate, err := utils.ParseBusinessDate(startDate); err != nil {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid start_date format", "start_date must be in YYYY-MM-DD format")
 			return
 		} else {
-			startOfDay := parsedStartDate.Format("2006-01-02 00:00:00")
-			query = query.Where("created_at >= ?", startOfDay)
+			query = query.Where("created_at >= ?", parsedStartDate)
 		}
 	}
 
 	if endDate != "" {
 		// Parse end date and set time to end of day
-		if parsedEndDate, err := time.Parse("2006-01-02", endDate); err != nil {
+		if parsedEndDate, err := utils.ParseBusinessDate(endDate); err != nil {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid end_date format", "end_date must be in YYYY-MM-DD format")
 			return
 		} else {
-			// Add 24 hours to get the start of next day, then use < instead of <=
-			nextDay := parsedEndDate.AddDate(0, 0, 1).Format("2006-01-02 00:00:00")
+			// Add a day to get the start of next day, then use < instead of <=
+			nextDay := parsedEndDate.AddDate(0, 0, 1)
 			query = query.Where("created_at < ?", nextDay)
 		}
 	}
@@ -260,7 +654,7 @@ func (oc *OrderController) GetOrder(c *gin.Context) {
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with order details.
+// @Description Create a new order with order details. Bundle SKUs are exploded into their component SKUs for picking.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -292,8 +686,8 @@ func (oc *OrderController) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	// Parse processing limit
-	processingLimit, err := time.Parse("2006-01-02 15:04:05", req.ProcessingLimit)
+	// Parse processing limit, given in the business timezone
+	processingLimit, err := utils.ParseBusinessTime("2006-01-02 15:04:05", req.ProcessingLimit)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid processing_limit format", "processing_limit must be in format YYYY-MM-DD HH:MM:SS")
 		return
@@ -309,7 +703,7 @@ func (oc *OrderController) CreateOrder(c *gin.Context) {
 	// Create order
 	order := models.Order{
 		OrderGineeID:    req.OrderGineeID,
-		Status:          "ready to pick", // Always set to "ready to pick"
+		Status:          models.OrderStatusReadyToPick, // Always set to "ready to pick"
 		Type:            req.Type,
 		Channel:         req.Channel,
 		Store:           req.Store,
@@ -333,7 +727,9 @@ func (oc *OrderController) CreateOrder(c *gin.Context) {
 	}
 
 	// Create order with details in a transaction
-	if err := oc.DB.Create(&order).Error; err != nil {
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		return createOrderWithDetails(tx, &order, models.OrderStationImport, "Created manually")
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create order", err.Error())
 		return
 	}
@@ -346,13 +742,13 @@ func (oc *OrderController) CreateOrder(c *gin.Context) {
 
 // BulkCreateOrders godoc
 // @Summary Bulk create orders
-// @Description Create multiple orders at once, skipping duplicates.
+// @Description Queue a background job that creates multiple orders at once, skipping duplicates. Orders are inserted in batches; with all_or_nothing no order is created when any order fails. Bundle SKUs are exploded into their component SKUs for picking. Poll GET /api/jobs/{id}; the result of the job is a BulkCreateOrderResponse.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body BulkCreateOrderRequest true "Bulk create order request"
-// @Success 201 {object} utils.Response{data=BulkCreateOrderResponse}
+// @Success 202 {object} utils.Response{data=models.JobResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
@@ -378,111 +774,233 @@ func (oc *OrderController) BulkCreateOrders(c *gin.Context) {
 		return
 	}
 
-	var createdOrders []models.Order
-	var skippedOrders []SkippedOrder
-	var failedOrders []FailedOrder
+	queueJob(c, oc.DB, models.JobTypeBulkCreateOrders, req, importerID, "Bulk order creation queued")
+}
 
-	for i, orderReq := range req.Orders {
-		// Check if order with same OrderGineeID already exists
-		var existingOrder models.Order
-		if err := oc.DB.Where("order_ginee_id = ?", orderReq.OrderGineeID).First(&existingOrder).Error; err == nil {
-			// Order exists, skip it
-			skippedOrders = append(skippedOrders, SkippedOrder{
-				Index:        i,
-				OrderGineeID: orderReq.OrderGineeID,
-				Reason:       "Order already exists",
-			})
-			continue
-		}
+// newGineeOrder builds a "ready to pick" Ginee order with its details from the request
+func newGineeOrder(orderReq CreateOrderRequest, importerID *uint) (models.Order, error) {
+	// Parse processing limit, given in the business timezone
+	processingLimit, err := utils.ParseBusinessTime("2006-01-02 15:04:05", orderReq.ProcessingLimit)
+	if err != nil {
+		return models.Order{}, errors.New("Invalid processing_limit format: " + err.Error())
+	}
 
-		// Parse processing limit
-		processingLimit, err := time.Parse("2006-01-02 15:04:05", orderReq.ProcessingLimit)
-		if err != nil {
-			failedOrders = append(failedOrders, FailedOrder{
-				Index:        i,
-				OrderGineeID: orderReq.OrderGineeID,
-				Error:        "Invalid processing_limit format: " + err.Error(),
-			})
-			continue
+	order := models.Order{
+		OrderGineeID:    orderReq.OrderGineeID,
+		Status:          models.OrderStatusReadyToPick, // Always set to "ready to pick"
+		Type:            "From Ginee",                  // Always set to "From Ginee"
+		Channel:         orderReq.Channel,
+		Store:           orderReq.Store,
+		Buyer:           orderReq.Buyer,
+		Address:         orderReq.Address,
+		Courier:         orderReq.Courier,
+		Tracking:        orderReq.Tracking,
+		ImporterID:      importerID,
+		ProcessingLimit: processingLimit,
+	}
+
+	// Create order details
+	for _, detailReq := range orderReq.OrderDetails {
+		order.OrderDetails = append(order.OrderDetails, models.OrderDetail{
+			Sku:         detailReq.Sku,
+			ProductName: detailReq.ProductName,
+			Variant:     detailReq.Variant,
+			Quantity:    detailReq.Quantity,
+		})
+	}
+
+	return order, nil
+}
+
+// createOrderWithDetails creates the order with its details, exploding bundle SKUs into their components, and records
+// its created event at the station with the note
+func createOrderWithDetails(tx *gorm.DB, order *models.Order, station, note string) error {
+	if err := tx.Create(order).Error; err != nil {
+		return err
+	}
+	if err := models.ExplodeBundles(tx, order); err != nil {
+		return err
+	}
+
+	event, err := models.NewOrderCreatedEvent(order, station, note)
+	if err != nil {
+		return err
+	}
+	return models.RecordOrderEvents(tx, event)
+}
+
+// PreviewGineeImport godoc
+// @Summary Preview Ginee order export import
+// @Description Parse a Ginee order export (.csv or .xlsx) without creating orders. Rows are grouped into orders by order ID, with one order detail per row. Each order is reported as ready, exists (will be skipped) or invalid (will fail) with its errors.
+// @Tags orders
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Ginee order export (.csv or .xlsx)"
+// @Success 200 {object} utils.Response{data=GineeImportPreviewResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/orders/import/preview [post]
+func (oc *OrderController) PreviewGineeImport(c *gin.Context) {
+	parsed, ok := readGineeOrderExport(c)
+	if !ok {
+		return
+	}
+
+	// Look up existing order IDs and trackings in one query each
+	orderGineeIDs := make([]string, 0, len(parsed))
+	trackings := make([]string, 0, len(parsed))
+	for _, order := range parsed {
+		orderGineeIDs = append(orderGineeIDs, order.Order.OrderGineeID)
+		if order.Order.Tracking != "" {
+			trackings = append(trackings, order.Order.Tracking)
 		}
+	}
+
+	var existingOrders []models.Order
+	if err := oc.DB.Select("order_ginee_id", "tracking").
+		Where("order_ginee_id IN ? OR tracking IN ?", orderGineeIDs, trackings).
+		Find(&existingOrders).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to check existing orders", err.Error())
+		return
+	}
+	existingIDs := make(map[string]bool)
+	trackingOwners := make(map[string]string)
+	for _, order := range existingOrders {
+		existingIDs[order.OrderGineeID] = true
+		trackingOwners[order.Tracking] = order.OrderGineeID
+	}
 
-		// Create order
-		order := models.Order{
-			OrderGineeID:    orderReq.OrderGineeID,
-			Status:          "ready to pick", // Always set to "ready to pick"
-			Type:            "From Ginee",    // Always set to "From Ginee"
-			Channel:         orderReq.Channel,
-			Store:           orderReq.Store,
-			Buyer:           orderReq.Buyer,
-			Address:         orderReq.Address,
-			Courier:         orderReq.Courier,
-			Tracking:        orderReq.Tracking,
-			ImporterID:      &importerID,
-			ProcessingLimit: processingLimit,
-		}
-
-		// Create order details
-		for _, detailReq := range orderReq.OrderDetails {
-			orderDetail := models.OrderDetail{
-				Sku:         detailReq.Sku,
-				ProductName: detailReq.ProductName,
-				Variant:     detailReq.Variant,
-				Quantity:    detailReq.Quantity,
+	response := GineeImportPreviewResponse{Orders: make([]GineeImportPreviewOrder, len(parsed))}
+	fileTrackings := make(map[string]string)
+	for i, order := range parsed {
+		errors := append([]string{}, order.Errors...)
+		if tracking := order.Order.Tracking; tracking != "" {
+			if owner, exists := trackingOwners[tracking]; exists && owner != order.Order.OrderGineeID {
+				errors = append(errors, fmt.Sprintf("tracking '%s' is already used by order '%s'", tracking, owner))
+			} else if owner, exists := fileTrackings[tracking]; exists {
+				errors = append(errors, fmt.Sprintf("tracking '%s' is also used by order '%s' in this file", tracking, owner))
+			} else {
+				fileTrackings[tracking] = order.Order.OrderGineeID
 			}
-			order.OrderDetails = append(order.OrderDetails, orderDetail)
 		}
 
-		// Try to create the order
-		if err := oc.DB.Create(&order).Error; err != nil {
-			// Failed to create order
-			failedOrders = append(failedOrders, FailedOrder{
+		status := GineeImportReady
+		switch {
+		case len(errors) > 0:
+			status = GineeImportInvalid
+			response.Summary.Invalid++
+		case existingIDs[order.Order.OrderGineeID]:
+			status = GineeImportExists
+			response.Summary.Existing++
+		default:
+			response.Summary.Ready++
+		}
+		response.Summary.Rows += len(order.Rows)
+
+		response.Orders[i] = GineeImportPreviewOrder{
+			Index:  i,
+			Rows:   order.Rows,
+			Status: status,
+			Errors: errors,
+			Order:  order.Order,
+		}
+	}
+	response.Summary.Orders = len(parsed)
+
+	utils.SuccessResponse(c, http.StatusOK, "Ginee import preview generated successfully", response)
+}
+
+// ImportGineeOrders godoc
+// @Summary Import Ginee order export
+// @Description Queue a background job that creates the orders of a Ginee order export (.csv or .xlsx) as in the bulk creation. The file is parsed before the job is queued. Orders that already exist are skipped; invalid orders fail with the errors shown in the preview. Indexes in the report refer to the orders of the preview. With all_or_nothing no order is created when any order is invalid or fails. Poll GET /api/jobs/{id}; the result of the job is a BulkCreateOrderResponse.
+// @Tags orders
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Ginee order export (.csv or .xlsx)"
+// @Param all_or_nothing formData bool false "Create no orders when any order fails" default(false)
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/orders/import [post]
+func (oc *OrderController) ImportGineeOrders(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	// Convert userID to uint
+	importerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	parsed, ok := readGineeOrderExport(c)
+	if !ok {
+		return
+	}
+
+	// Only valid orders are created; the report keeps the indexes of the parsed file
+	var validOrders []CreateOrderRequest
+	var validIndexes []int
+	var invalidOrders []FailedOrder
+	for i, order := range parsed {
+		if len(order.Errors) > 0 {
+			invalidOrders = append(invalidOrders, FailedOrder{
 				Index:        i,
-				OrderGineeID: orderReq.OrderGineeID,
-				Error:        err.Error(),
+				OrderGineeID: order.Order.OrderGineeID,
+				Error:        strings.Join(order.Errors, "; "),
 			})
 			continue
 		}
+		validOrders = append(validOrders, order.Order)
+		validIndexes = append(validIndexes, i)
+	}
 
-		// Load order with details for response
-		oc.DB.Preload("OrderDetails").Preload("Picker").First(&order, order.ID)
-		createdOrders = append(createdOrders, order)
+	params := GineeImportJobParams{
+		Orders:        validOrders,
+		Indexes:       validIndexes,
+		InvalidOrders: invalidOrders,
+		Total:         len(parsed),
+		AllOrNothing:  c.PostForm("all_or_nothing") == "true",
 	}
 
-	// Convert created orders to response format
-	createdOrderResponses := make([]models.OrderResponse, len(createdOrders))
-	for i, order := range createdOrders {
-		createdOrderResponses[i] = order.ToOrderResponse()
+	queueJob(c, oc.DB, models.JobTypeImportGineeOrders, params, importerID, "Ginee order import queued")
+}
+
+// readGineeOrderExport reads and parses the uploaded Ginee order export.
+// It writes the error response and returns false when the file cannot be read.
+func readGineeOrderExport(c *gin.Context) ([]gineeExportOrder, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is required", err.Error())
+		return nil, false
 	}
 
-	response := BulkCreateOrderResponse{
-		Summary: BulkCreateSummary{
-			Total:   len(req.Orders),
-			Created: len(createdOrders),
-			Skipped: len(skippedOrders),
-			Failed:  len(failedOrders),
-		},
-		CreatedOrders: createdOrderResponses,
-		SkippedOrders: skippedOrders,
-		FailedOrders:  failedOrders,
+	rows, err := utils.ReadSpreadsheet(fileHeader)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file", err.Error())
+		return nil, false
 	}
 
-	// Determine response status
-	statusCode := http.StatusCreated
-	message := "Bulk order creation completed"
+	parsed, err := parseGineeOrderExport(rows)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid Ginee export", err.Error())
+		return nil, false
+	}
 
-	if len(createdOrders) == 0 {
-		if len(skippedOrders) > 0 {
-			statusCode = http.StatusOK
-			message = "All orders were skipped (already exist)"
-		} else {
-			statusCode = http.StatusBadRequest
-			message = "No orders could be created"
-		}
-	} else if len(failedOrders) > 0 || len(skippedOrders) > 0 {
-		message = "Bulk order creation completed with some issues"
+	if len(parsed) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is empty", "the file contains no orders")
+		return nil, false
 	}
 
-	utils.SuccessResponse(c, statusCode, message, response)
+	return parsed, true
 }
 
 // GetOrderDetails godoc
@@ -511,16 +1029,20 @@ func (oc *OrderController) GetOrderDetails(c *gin.Context) {
 		return
 	}
 
-	// Convert order details to response format
-	orderDetails := make([]OrderDetailResponse, len(order.OrderDetails))
-	for i, detail := range order.OrderDetails {
-		orderDetails[i] = OrderDetailResponse{
-			ID:          detail.ID,
-			Sku:         detail.Sku,
-			ProductName: detail.ProductName,
-			Variant:     detail.Variant,
-			Quantity:    detail.Quantity,
+	// Convert order details to response format, with bundle components under their bundle line
+	var orderDetails []models.OrderDetailResponse
+	for _, detail := range order.OrderDetails {
+		if detail.BundleDetailID != nil {
+			continue
+		}
+
+		detailResp := detail.ToOrderDetailResponse()
+		for _, component := range order.OrderDetails {
+			if component.BundleDetailID != nil && *component.BundleDetailID == detail.ID {
+				detailResp.Components = append(detailResp.Components, component.ToOrderDetailResponse())
+			}
 		}
+		orderDetails = append(orderDetails, detailResp)
 	}
 
 	// Create custom response with only order_ginee_id, tracking, and order details
@@ -535,7 +1057,7 @@ func (oc *OrderController) GetOrderDetails(c *gin.Context) {
 
 // UpdateOrderDetail godoc
 // @Summary Update order detail
-// @Description Update a specific order detail by ID (coordinator only)
+// @Description Update a specific order detail by ID (coordinator only). Bundle components are rebuilt from the new SKU and quantity; components cannot be updated themselves.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -543,7 +1065,7 @@ func (oc *OrderController) GetOrderDetails(c *gin.Context) {
 // @Param id path int true "Order ID"
 // @Param detail_id path int true "Order Detail ID"
 // @Param request body UpdateOrderDetailRequest true "Update order detail request"
-// @Success 200 {object} utils.Response{data=OrderDetailResponse}
+// @Success 200 {object} utils.Response{data=models.OrderDetailResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
@@ -553,6 +1075,19 @@ func (oc *OrderController) UpdateOrderDetail(c *gin.Context) {
 	orderID := c.Param("id")
 	detailID := c.Param("detail_id")
 
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	actorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
 	var req UpdateOrderDetailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ValidationErrorResponse(c, err)
@@ -571,7 +1106,7 @@ func (oc *OrderController) UpdateOrderDetail(c *gin.Context) {
 	}
 
 	// Check if order status allows modification
-	if order.Status != "ready to pick" {
+	if order.Status != models.OrderStatusReadyToPick {
 		utils.ErrorResponse(c, http.StatusForbidden, "Order modification not allowed", fmt.Sprintf("cannot modify order details when status is '%s'. Order must be in 'ready to pick' status", order.Status))
 		return
 	}
@@ -587,23 +1122,49 @@ func (oc *OrderController) UpdateOrderDetail(c *gin.Context) {
 		return
 	}
 
-	// Update fields
+	if orderDetail.BundleDetailID != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Bundle component cannot be modified", "bundle components follow their bundle line, update the bundle line instead")
+		return
+	}
+
+	// Update fields, keeping the previous values for the order history
+	previousDetail := orderDetail
 	orderDetail.Sku = req.Sku
 	orderDetail.ProductName = req.ProductName
 	orderDetail.Variant = req.Variant
 	orderDetail.Quantity = req.Quantity
 
-	if err := oc.DB.Save(&orderDetail).Error; err != nil {
+	var components []models.OrderDetail
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&orderDetail).Error; err != nil {
+			return err
+		}
+
+		// Rebuild the components for the new SKU and quantity
+		if orderDetail.IsBundle {
+			if err := models.RemoveBundleComponents(tx, &orderDetail); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if components, err = models.ExplodeBundleDetail(tx, &orderDetail); err != nil {
+			return err
+		}
+
+		event, err := models.NewOrderDetailEvent(order.ID, models.OrderEventDetailUpdated, &previousDetail, &orderDetail, &actorID)
+		if err != nil {
+			return err
+		}
+		return models.RecordOrderEvents(tx, event)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update order detail", err.Error())
 		return
 	}
 
-	response := OrderDetailResponse{
-		ID:          orderDetail.ID,
-		Sku:         orderDetail.Sku,
-		ProductName: orderDetail.ProductName,
-		Variant:     orderDetail.Variant,
-		Quantity:    orderDetail.Quantity,
+	response := orderDetail.ToOrderDetailResponse()
+	for _, component := range components {
+		response.Components = append(response.Components, component.ToOrderDetailResponse())
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Order detail updated successfully", response)
@@ -611,14 +1172,14 @@ func (oc *OrderController) UpdateOrderDetail(c *gin.Context) {
 
 // AddOrderDetail godoc
 // @Summary Add new order detail
-// @Description Add a new order detail to an existing order (coordinator only)
+// @Description Add a new order detail to an existing order (coordinator only). A bundle SKU is exploded into its component SKUs.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
 // @Param request body CreateOrderDetailRequest true "Add order detail request"
-// @Success 201 {object} utils.Response{data=OrderDetailResponse}
+// @Success 201 {object} utils.Response{data=models.OrderDetailResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
@@ -627,6 +1188,19 @@ func (oc *OrderController) UpdateOrderDetail(c *gin.Context) {
 func (oc *OrderController) AddOrderDetail(c *gin.Context) {
 	orderID := c.Param("id")
 
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	actorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
 	var req CreateOrderDetailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ValidationErrorResponse(c, err)
@@ -645,7 +1219,7 @@ func (oc *OrderController) AddOrderDetail(c *gin.Context) {
 	}
 
 	// Check if order status allows modification
-	if order.Status != "ready to pick" {
+	if order.Status != models.OrderStatusReadyToPick {
 		utils.ErrorResponse(c, http.StatusForbidden, "Order modification not allowed", fmt.Sprintf("cannot add order details when status is '%s'. Order must be in 'ready to pick' status", order.Status))
 		return
 	}
@@ -666,17 +1240,30 @@ func (oc *OrderController) AddOrderDetail(c *gin.Context) {
 		Quantity:    req.Quantity,
 	}
 
-	if err := oc.DB.Create(&orderDetail).Error; err != nil {
+	var components []models.OrderDetail
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&orderDetail).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if components, err = models.ExplodeBundleDetail(tx, &orderDetail); err != nil {
+			return err
+		}
+
+		event, err := models.NewOrderDetailEvent(order.ID, models.OrderEventDetailAdded, nil, &orderDetail, &actorID)
+		if err != nil {
+			return err
+		}
+		return models.RecordOrderEvents(tx, event)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to add order detail", err.Error())
 		return
 	}
 
-	response := OrderDetailResponse{
-		ID:          orderDetail.ID,
-		Sku:         orderDetail.Sku,
-		ProductName: orderDetail.ProductName,
-		Variant:     orderDetail.Variant,
-		Quantity:    orderDetail.Quantity,
+	response := orderDetail.ToOrderDetailResponse()
+	for _, component := range components {
+		response.Components = append(response.Components, component.ToOrderDetailResponse())
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, "Order detail added successfully", response)
@@ -684,7 +1271,7 @@ func (oc *OrderController) AddOrderDetail(c *gin.Context) {
 
 // RemoveOrderDetail godoc
 // @Summary Remove order detail
-// @Description Remove a specific order detail from an order (admin only)
+// @Description Remove a specific order detail from an order (admin only). Removing a bundle line removes its components; components cannot be removed themselves.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -701,6 +1288,19 @@ func (oc *OrderController) RemoveOrderDetail(c *gin.Context) {
 	orderID := c.Param("id")
 	detailID := c.Param("detail_id")
 
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	actorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
 	// Verify order exists
 	var order models.Order
 	if err := oc.DB.First(&order, orderID).Error; err != nil {
@@ -713,14 +1313,14 @@ func (oc *OrderController) RemoveOrderDetail(c *gin.Context) {
 	}
 
 	// Check if order status allows modification
-	if order.Status != "ready to pick" {
+	if order.Status != models.OrderStatusReadyToPick {
 		utils.ErrorResponse(c, http.StatusForbidden, "Order modification not allowed", fmt.Sprintf("cannot remove order details when status is '%s'. Order must be in 'ready to pick' status", order.Status))
 		return
 	}
 
-	// Check if this is the last order detail
+	// Check if this is the last order detail (bundle components are not counted)
 	var detailCount int64
-	oc.DB.Model(&models.OrderDetail{}).Where("order_id = ?", orderID).Count(&detailCount)
+	oc.DB.Model(&models.OrderDetail{}).Where("order_id = ? AND bundle_detail_id IS NULL", orderID).Count(&detailCount)
 	if detailCount <= 1 {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot remove order detail", "order must have at least one order detail")
 		return
@@ -737,7 +1337,25 @@ func (oc *OrderController) RemoveOrderDetail(c *gin.Context) {
 		return
 	}
 
-	if err := oc.DB.Delete(&orderDetail).Error; err != nil {
+	if orderDetail.BundleDetailID != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Bundle component cannot be removed", "bundle components follow their bundle line, remove the bundle line instead")
+		return
+	}
+
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_detail_id = ?", orderDetail.ID).Delete(&models.OrderDetail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&orderDetail).Error; err != nil {
+			return err
+		}
+
+		event, err := models.NewOrderDetailEvent(order.ID, models.OrderEventDetailRemoved, &orderDetail, nil, &actorID)
+		if err != nil {
+			return err
+		}
+		return models.RecordOrderEvents(tx, event)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to remove order detail", err.Error())
 		return
 	}
@@ -781,7 +1399,17 @@ type CreateOrderDetailRequest struct {
 }
 
 type BulkCreateOrderRequest struct {
-	Orders []CreateOrderRequest `json:"orders" binding:"required,min=1"`
+	Orders       []CreateOrderRequest `json:"orders" binding:"required,min=1"`
+	AllOrNothing bool                 `json:"all_or_nothing" example:"false"`
+}
+
+// GineeImportJobParams are the parsed orders of a Ginee export, queued for import
+type GineeImportJobParams struct {
+	Orders        []CreateOrderRequest `json:"orders"`
+	Indexes       []int                `json:"indexes"`
+	InvalidOrders []FailedOrder        `json:"invalid_orders"`
+	Total         int                  `json:"total"`
+	AllOrNothing  bool                 `json:"all_or_nothing"`
 }
 
 type BulkCreateOrderResponse struct {
@@ -792,10 +1420,39 @@ type BulkCreateOrderResponse struct {
 }
 
 type BulkCreateSummary struct {
-	Total   int `json:"total"`
-	Created int `json:"created"`
-	Skipped int `json:"skipped"`
-	Failed  int `json:"failed"`
+	Total        int  `json:"total"`
+	Created      int  `json:"created"`
+	Skipped      int  `json:"skipped"`
+	Failed       int  `json:"failed"`
+	AllOrNothing bool `json:"all_or_nothing"`
+}
+
+// Ginee import preview statuses
+const (
+	GineeImportReady   = "ready"
+	GineeImportExists  = "exists"
+	GineeImportInvalid = "invalid"
+)
+
+type GineeImportPreviewResponse struct {
+	Summary GineeImportPreviewSummary `json:"summary"`
+	Orders  []GineeImportPreviewOrder `json:"orders"`
+}
+
+type GineeImportPreviewSummary struct {
+	Rows     int `json:"rows"`
+	Orders   int `json:"orders"`
+	Ready    int `json:"ready"`
+	Existing int `json:"existing"`
+	Invalid  int `json:"invalid"`
+}
+
+type GineeImportPreviewOrder struct {
+	Index  int                `json:"index"`
+	Rows   []int              `json:"rows"`
+	Status string             `json:"status"`
+	Errors []string           `json:"errors"`
+	Order  CreateOrderRequest `json:"order"`
 }
 
 type SkippedOrder struct {
@@ -810,16 +1467,8 @@ type FailedOrder struct {
 	Error        string `json:"error"`
 }
 
-type OrderDetailResponse struct {
-	ID          uint   `json:"id"`
-	Sku         string `json:"sku"`
-	ProductName string `json:"product_name"`
-	Variant     string `json:"variant"`
-	Quantity    int    `json:"quantity"`
-}
-
 type OrderDetailsOnlyResponse struct {
-	OrderGineeID string                `json:"order_ginee_id"`
-	Tracking     string                `json:"tracking"`
-	OrderDetails []OrderDetailResponse `json:"order_details"`
+	OrderGineeID string                       `json:"order_ginee_id"`
+	Tracking     string                       `json:"tracking"`
+	OrderDetails []models.OrderDetailResponse `json:"order_details"`
 }
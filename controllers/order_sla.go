@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultSlaDueWithinHours is the window in which an open order counts as due soon when none is requested
+const defaultSlaDueWithinHours = 4
+
+// GetOrderSla godoc
+// @Summary Get processing limit SLA overview
+// @Description Bucket the open orders (ready to pick up to packed) by their processing limit into overdue, due within due_within_hours of working time and on track, in total and per store and courier. Orders flagged at risk by the SLA checker are counted separately.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param due_within_hours query int false "Window for due soon orders in working hours" default(4)
+// @Param channel query string false "Filter by channel"
+// @Param store query string false "Filter by store"
+// @Param courier query string false "Filter by courier"
+// @Success 200 {object} utils.Response{data=OrderSlaResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /api/orders/sla [get]
+func (oc *OrderController) GetOrderSla(c *gin.Context) {
+	dueWithin, ok := parseSlaDueWithin(c)
+	if !ok {
+		return
+	}
+
+	now := utils.BusinessNow()
+	dueSoonCutoff := models.SlaDueSoonCutoff(now, dueWithin)
+	query := oc.slaOrdersQuery(c).Select(`store, courier,
+		COUNT(*) FILTER (WHERE processing_limit < ?) AS overdue,
+		COUNT(*) FILTER (WHERE processing_limit >= ? AND processing_limit < ?) AS due_soon,
+		COUNT(*) FILTER (WHERE processing_limit >= ?) AS on_track,
+		COUNT(*) FILTER (WHERE sla_flag = ?) AS at_risk,
+		COUNT(*) AS total`,
+		now, now, dueSoonCutoff, dueSoonCutoff, models.SlaFlagAtRisk).
+		Group("store, courier")
+
+	var groups []OrderSlaGroup
+	if err := query.Scan(&groups).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to build SLA overview", err.Error())
+		return
+	}
+
+	// Most urgent groups first
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Overdue != groups[j].Overdue {
+			return groups[i].Overdue > groups[j].Overdue
+		}
+		if groups[i].DueSoon != groups[j].DueSoon {
+			return groups[i].DueSoon > groups[j].DueSoon
+		}
+		if groups[i].Store != groups[j].Store {
+			return groups[i].Store < groups[j].Store
+		}
+		return groups[i].Courier < groups[j].Courier
+	})
+
+	response := OrderSlaResponse{
+		GeneratedAt:    now,
+		DueWithinHours: int(dueWithin / time.Hour),
+		Groups:         groups,
+	}
+	if response.Groups == nil {
+		response.Groups = []OrderSlaGroup{}
+	}
+	for _, group := range groups {
+		response.Totals.Overdue += group.Overdue
+		response.Totals.DueSoon += group.DueSoon
+		response.Totals.OnTrack += group.OnTrack
+		response.Totals.AtRisk += group.AtRisk
+		response.Totals.Total += group.Total
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "SLA overview retrieved successfully", response)
+}
+
+// GetPriorityQueue godoc
+// @Summary Get picking priority queue
+// @Description Get the orders that are ready to pick sorted by processing limit, most urgent first, with their SLA bucket, the minutes left until the processing limit (negative when overdue) and the working minutes left.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param due_within_hours query int false "Window for due soon orders in working hours" default(4)
+// @Param channel query string false "Filter by channel"
+// @Param store query string false "Filter by store"
+// @Param courier query string false "Filter by courier"
+// @Success 200 {object} utils.Response{data=PriorityQueueResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /api/orders/priority-queue [get]
+func (oc *OrderController) GetPriorityQueue(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	dueWithin, ok := parseSlaDueWithin(c)
+	if !ok {
+		return
+	}
+
+	var orders []models.Order
+	var total int64
+
+	query := oc.slaOrdersQuery(c).Where("status = ?", models.OrderStatusReadyToPick)
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count orders", err.Error())
+		return
+	}
+
+	if err := query.Order("processing_limit ASC, id ASC").Limit(limit).Offset(offset).
+		Preload("OrderDetails").
+		Find(&orders).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve orders", err.Error())
+		return
+	}
+
+	now := utils.BusinessNow()
+	dueSoonCutoff := models.SlaDueSoonCutoff(now, dueWithin)
+	calendar := utils.BusinessCalendar()
+	response := PriorityQueueResponse{
+		Orders: make([]PriorityQueueOrder, len(orders)),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+	for i, order := range orders {
+		response.Orders[i] = PriorityQueueOrder{
+			Order:              order.ToOrderResponse(),
+			SlaStatus:          models.SlaStatus(order.ProcessingLimit, now, dueSoonCutoff),
+			MinutesLeft:        int(order.ProcessingLimit.Sub(now).Minutes()),
+			WorkingMinutesLeft: int(calendar.WorkingDuration(now, order.ProcessingLimit).Minutes()),
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Priority queue retrieved successfully", response)
+}
+
+// CheckOrderSla flags the open orders that will miss their processing limit. It runs periodically in the background.
+func (oc *OrderController) CheckOrderSla(ctx context.Context) error {
+	result, err := models.FlagOrdersAtRisk(oc.DB.WithContext(ctx), utils.BusinessNow())
+	if err != nil {
+		return err
+	}
+
+	if result.AtRisk > 0 || result.Missed > 0 {
+		log.Printf("⏰ SLA check: %d orders at risk, %d orders missed their processing limit", result.AtRisk, result.Missed)
+	}
+	return nil
+}
+
+// slaOrdersQuery returns the open orders with the channel, store and courier filters of the request
+func (oc *OrderController) slaOrdersQuery(c *gin.Context) *gorm.DB {
+	query := oc.DB.Model(&models.Order{}).Where("status IN ?", models.OpenOrderStatuses())
+
+	if channel := c.Query("channel"); channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+
+	if store := c.Query("store"); store != "" {
+		query = query.Where("store = ?", store)
+	}
+
+	if courier := c.Query("courier"); courier != "" {
+		query = query.Where("courier = ?", courier)
+	}
+
+	return query
+}
+
+// parseSlaDueWithin parses the due_within_hours query parameter and responds when it is invalid
+func parseSlaDueWithin(c *gin.Context) (time.Duration, bool) {
+	hours, err := strconv.Atoi(c.DefaultQuery("due_within_hours", strconv.Itoa(defaultSlaDueWithinHours)))
+	if err != nil || hours < 1 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid due_within_hours", "due_within_hours must be a positive number of hours")
+		return 0, false
+	}
+	return time.Duration(hours) * time.Hour, true
+}
+
+// Request/Response structs
+type OrderSlaCounts struct {
+	Overdue int `json:"overdue"`
+	DueSoon int `json:"due_soon"`
+	OnTrack int `json:"on_track"`
+	AtRisk  int `json:"at_risk"`
+	Total   int `json:"total"`
+}
+
+type OrderSlaGroup struct {
+	Store   string `json:"store"`
+	Courier string `json:"courier"`
+	Overdue int    `json:"overdue"`
+	DueSoon int    `json:"due_soon"`
+	OnTrack int    `json:"on_track"`
+	AtRisk  int    `json:"at_risk"`
+	Total   int    `json:"total"`
+}
+
+type OrderSlaResponse struct {
+	GeneratedAt    time.Time       `json:"generated_at"`
+	DueWithinHours int             `json:"due_within_hours"`
+	Totals         OrderSlaCounts  `json:"totals"`
+	Groups         []OrderSlaGroup `json:"groups"`
+}
+
+type PriorityQueueOrder struct {
+	Order              models.OrderResponse `json:"order"`
+	SlaStatus          string               `json:"sla_status"`
+	MinutesLeft        int                  `json:"minutes_left"`
+	WorkingMinutesLeft int                  `json:"working_minutes_left"`
+}
+
+type PriorityQueueResponse struct {
+	Orders     []PriorityQueueOrder     `json:"orders"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetOrderTimeline godoc
+// @Summary Get order timeline
+// @Description Get the full history of an order, oldest first: its creation, every status change at the pick, QC, outbound, manifest and return stations, detail and complained changes and cancellation, with the actor and the values before and after each change. The journey lists the stations the parcel went through with the first and last time it was handled there.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=OrderTimelineResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/orders/{id}/timeline [get]
+func (oc *OrderController) GetOrderTimeline(c *gin.Context) {
+	orderID := c.Param("id")
+
+	var order models.Order
+	if err := oc.DB.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Order not found", "no order found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find order", err.Error())
+		return
+	}
+
+	var events []models.OrderEvent
+	if err := oc.DB.Where("order_id = ?", order.ID).Preload("Actor").Order("created_at ASC, id ASC").Find(&events).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve order timeline", err.Error())
+		return
+	}
+
+	response := OrderTimelineResponse{
+		OrderID:      order.ID,
+		OrderGineeID: order.OrderGineeID,
+		Tracking:     order.Tracking,
+		Status:       order.Status,
+		Journey:      []OrderTimelineStation{},
+		Events:       make([]models.OrderEventResponse, len(events)),
+	}
+
+	stations := make(map[string]int)
+	for i, event := range events {
+		response.Events[i] = event.ToOrderEventResponse()
+
+		// Stations are listed in the order the parcel first reached them
+		index, visited := stations[event.Station]
+		if !visited {
+			index = len(response.Journey)
+			stations[event.Station] = index
+			response.Journey = append(response.Journey, OrderTimelineStation{
+				Station: event.Station,
+				FirstAt: event.CreatedAt,
+			})
+		}
+		response.Journey[index].LastAt = event.CreatedAt
+		response.Journey[index].Events++
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Order timeline retrieved successfully", response)
+}
+
+// Request/Response structs
+type OrderTimelineStation struct {
+	Station string    `json:"station"`
+	FirstAt time.Time `json:"first_at"`
+	LastAt  time.Time `json:"last_at"`
+	Events  int       `json:"events"`
+}
+
+type OrderTimelineResponse struct {
+	OrderID      uint                        `json:"order_id"`
+	OrderGineeID string                      `json:"order_ginee_id"`
+	Tracking     string                      `json:"tracking"`
+	Status       string                      `json:"status"`
+	Journey      []OrderTimelineStation      `json:"journey"`
+	Events       []models.OrderEventResponse `json:"events"`
+}
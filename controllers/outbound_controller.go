@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboundController struct {
+	DB *gorm.DB
+}
+
+// NewOutboundController creates a new outbound controller
+func NewOutboundController(db *gorm.DB) *OutboundController {
+	return &OutboundController{DB: db}
+}
+
+// CreateOutbound godoc
+// @Summary Scan outbound
+// @Description Scan a tracking number at the outbound station. The expedition is detected from the longest matching tracking prefix. The order must be packed and not scanned yet. The order moves to "outbound".
+// @Tags outbounds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOutboundRequest true "Create outbound request"
+// @Success 201 {object} utils.Response{data=models.OutboundResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/outbounds [post]
+func (oc *OutboundController) CreateOutbound(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	operatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateOutboundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Tracking = strings.TrimSpace(req.Tracking)
+	if req.Tracking == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid tracking", "tracking is required")
+		return
+	}
+
+	// Reject duplicate scans
+	var existingCount int64
+	oc.DB.Model(&models.Outbound{}).Where("tracking = ?", req.Tracking).Count(&existingCount)
+	if existingCount > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Outbound already exists", fmt.Sprintf("tracking '%s' has already been scanned at outbound", req.Tracking))
+		return
+	}
+
+	expedition, err := models.MatchExpeditionByTracking(oc.DB, req.Tracking)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to detect expedition", err.Error())
+		return
+	}
+	if expedition == nil {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Unknown expedition", fmt.Sprintf("no expedition code matches the prefix of tracking '%s'", req.Tracking))
+		return
+	}
+
+	outbound := models.Outbound{
+		Tracking:        req.Tracking,
+		UserID:          operatorID,
+		Expedition:      expedition.Name,
+		ExpeditionColor: expedition.Color,
+		ExpeditionSlug:  expedition.Slug,
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := oc.DB.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tracking = ?", req.Tracking).First(&order).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no order found with tracking '%s'", req.Tracking)
+			}
+			return err
+		}
+
+		if order.Status == models.OrderStatusCancelled {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order with tracking '%s' has been cancelled", req.Tracking)
+		}
+
+		if order.Status != models.OrderStatusPacked {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order status is '%s'. Order must be in '%s' status", order.Status, models.OrderStatusPacked)
+		}
+
+		if err := tx.Create(&outbound).Error; err != nil {
+			return err
+		}
+
+		previousStatus := order.Status
+		if err := order.TransitionTo(models.OrderStatusOutbound, &operatorID); err != nil {
+			statusCode = http.StatusConflict
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(&order).Error; err != nil {
+			return err
+		}
+		return models.RecordOrderEvents(tx, models.NewOrderStatusEvent(&order, previousStatus, &operatorID, "Scanned for "+outbound.Expedition))
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to create outbound", err.Error())
+		return
+	}
+
+	oc.respondWithOutbound(c, http.StatusCreated, "Outbound created successfully", outbound.ID)
+}
+
+// GetOutbounds godoc
+// @Summary Get all outbounds
+// @Description Get list of outbound scans with pagination, optional date range filtering, expedition filter and search by tracking.
+// @Tags outbounds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Param expedition query string false "Filter by expedition slug"
+// @Param search query string false "Search by tracking number"
+// @Success 200 {object} utils.Response{data=OutboundsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/outbounds [get]
+func (oc *OutboundController) GetOutbounds(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	search := strings.TrimSpace(c.Query("search"))
+	expedition := strings.TrimSpace(c.Query("expedition"))
+
+	var outbounds []models.Outbound
+	var total int64
+
+	query := oc.DB.Model(&models.Outbound{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if expedition != "" {
+		query = query.Where("expedition_slug = ?", expedition)
+	}
+
+	if search != "" {
+		query = query.Where("tracking ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count outbounds", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("User").
+		Find(&outbounds).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve outbounds", err.Error())
+		return
+	}
+
+	response := OutboundsListResponse{
+		Outbounds: models.ToOutboundResponses(outbounds),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Outbounds retrieved successfully", response)
+}
+
+// GetOutbound godoc
+// @Summary Get outbound by ID
+// @Description Get an outbound scan with its operator and order.
+// @Tags outbounds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Outbound ID"
+// @Success 200 {object} utils.Response{data=models.OutboundResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/outbounds/{id} [get]
+func (oc *OutboundController) GetOutbound(c *gin.Context) {
+	outboundID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid outbound ID", "Outbound ID must be a valid number")
+		return
+	}
+
+	oc.respondWithOutbound(c, http.StatusOK, "Outbound retrieved successfully", uint(outboundID))
+}
+
+// respondWithOutbound loads the outbound scan with its operator and order
+func (oc *OutboundController) respondWithOutbound(c *gin.Context, statusCode int, message string, outboundID uint) {
+	var outbound models.Outbound
+	if err := oc.DB.Preload("User").First(&outbound, outboundID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Outbound not found", "no outbound found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve outbound", err.Error())
+		return
+	}
+
+	outbound.Order, _ = models.ResolveOrder(oc.DB, outbound.Tracking, "")
+
+	utils.SuccessResponse(c, statusCode, message, outbound.ToOutboundResponse())
+}
+
+// Request/Response structs
+type CreateOutboundRequest struct {
+	Tracking string `json:"tracking" binding:"required"`
+}
+
+type OutboundsListResponse struct {
+	Outbounds  []models.OutboundResponse `json:"outbounds"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
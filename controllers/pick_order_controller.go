@@ -0,0 +1,734 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PickOrderController struct {
+	DB *gorm.DB
+}
+
+// NewPickOrderController creates a new pick order controller
+func NewPickOrderController(db *gorm.DB) *PickOrderController {
+	return &PickOrderController{DB: db}
+}
+
+// ClaimOrder godoc
+// @Summary Claim order for picking
+// @Description Claim a "ready to pick" order by tracking number or Ginee order ID. The order moves to "picking" and a pick order is created for the current picker.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ClaimPickOrderRequest true "Claim pick order request"
+// @Success 201 {object} utils.Response{data=PickOrderProgressResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-orders/claim [post]
+func (pc *PickOrderController) ClaimOrder(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	pickerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req ClaimPickOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Tracking = strings.TrimSpace(req.Tracking)
+	req.OrderGineeID = strings.TrimSpace(req.OrderGineeID)
+	if req.Tracking == "" && req.OrderGineeID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Tracking or order ginee ID is required", "either tracking or order_ginee_id must be provided")
+		return
+	}
+
+	var pickOrder models.PickOrder
+	var alreadyClaimed bool
+	statusCode := http.StatusInternalServerError
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		// Find the order by tracking or Ginee order ID, locked so two pickers cannot claim it at once
+		var order models.Order
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		if req.Tracking != "" {
+			query = query.Where("tracking = ?", req.Tracking)
+		} else {
+			query = query.Where("order_ginee_id = ?", req.OrderGineeID)
+		}
+		if err := query.First(&order).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no order found with the specified tracking or order ginee ID")
+			}
+			return err
+		}
+
+		// Claiming an order that is already being picked by the same picker returns the existing pick order
+		if order.Status == models.OrderStatusPicking {
+			if err := tx.Where("order_id = ? AND picker_id = ?", order.ID, pickerID).Order("id DESC").First(&pickOrder).Error; err == nil {
+				alreadyClaimed = true
+				return nil
+			}
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order is being picked by another picker")
+		}
+
+		if order.Status != models.OrderStatusReadyToPick {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order status is '%s'. Order must be in '%s' status", order.Status, models.OrderStatusReadyToPick)
+		}
+
+		previousStatus := order.Status
+		if err := order.TransitionTo(models.OrderStatusPicking, &pickerID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(&order).Error; err != nil {
+			return err
+		}
+
+		pickOrder = models.PickOrder{
+			OrderID:  order.ID,
+			PickerID: pickerID,
+		}
+		if err := tx.Create(&pickOrder).Error; err != nil {
+			return err
+		}
+		return models.RecordOrderEvents(tx, models.NewOrderStatusEvent(&order, previousStatus, &pickerID, fmt.Sprintf("Claimed in pick order %d", pickOrder.ID)))
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to claim order", err.Error())
+		return
+	}
+
+	if alreadyClaimed {
+		pc.respondWithProgress(c, http.StatusOK, "Order already claimed by you", pickOrder.ID)
+		return
+	}
+
+	pc.respondWithProgress(c, http.StatusCreated, "Order claimed successfully", pickOrder.ID)
+}
+
+// GetMyPickOrders godoc
+// @Summary Get my pick orders
+// @Description Get the pick history of the current picker with pagination and optional date range filtering.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=PickOrdersListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/pick-orders/mine [get]
+func (pc *PickOrderController) GetMyPickOrders(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var pickOrders []models.PickOrder
+	var total int64
+
+	query := pc.DB.Model(&models.PickOrder{}).Where("picker_id = ?", userID)
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count pick orders", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("Order.OrderDetails").
+		Preload("PickOrderDetails").
+		Find(&pickOrders).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve pick orders", err.Error())
+		return
+	}
+
+	pickOrderResponses := make([]models.PickOrderResponse, len(pickOrders))
+	for i := range pickOrders {
+		pickOrders[i].LoadProducts(pc.DB)
+		pickOrderResponses[i] = pickOrders[i].ToPickOrderResponse()
+	}
+
+	response := PickOrdersListResponse{
+		PickOrders: pickOrderResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pick orders retrieved successfully", response)
+}
+
+// GetPickOrder godoc
+// @Summary Get pick order by ID
+// @Description Get a pick order with its picked items and the comparison against the order details.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Order ID"
+// @Success 200 {object} utils.Response{data=PickOrderProgressResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/pick-orders/{id} [get]
+func (pc *PickOrderController) GetPickOrder(c *gin.Context) {
+	pickOrderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid pick order ID", "pick order ID must be a valid number")
+		return
+	}
+
+	pc.respondWithProgress(c, http.StatusOK, "Pick order retrieved successfully", uint(pickOrderID))
+}
+
+// SubmitPickedItems godoc
+// @Summary Submit picked items
+// @Description Record the SKUs and quantities picked for a pick order, replacing previously recorded items, and compare them against the order details.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Order ID"
+// @Param request body SubmitPickedItemsRequest true "Submit picked items request"
+// @Success 200 {object} utils.Response{data=PickOrderProgressResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-orders/{id}/items [put]
+func (pc *PickOrderController) SubmitPickedItems(c *gin.Context) {
+	var req SubmitPickedItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	pickOrder, order, ok := pc.findActivePickOrder(c)
+	if !ok {
+		return
+	}
+
+	// Product names are taken from the order details, falling back to the product catalogue
+	names := make(map[string]models.PickRequirement)
+	for _, requirement := range order.PickRequirements() {
+		names[requirement.Sku] = requirement
+	}
+
+	// Merge items that share the same SKU
+	var details []models.PickOrderDetail
+	index := make(map[string]int)
+	for _, item := range req.Items {
+		sku := strings.TrimSpace(item.Sku)
+		if i, exists := index[sku]; exists {
+			details[i].Quantity += item.Quantity
+			continue
+		}
+
+		detail := models.PickOrderDetail{
+			PickOrderID: pickOrder.ID,
+			Sku:         sku,
+			ProductName: sku,
+			Quantity:    item.Quantity,
+		}
+
+		if requirement, exists := names[sku]; exists {
+			detail.ProductName = requirement.ProductName
+			detail.Variant = requirement.Variant
+		} else {
+			var product models.Product
+			if err := pc.DB.Where("sku = ?", sku).First(&product).Error; err == nil {
+				detail.ProductName = product.Name
+				detail.Variant = product.Variant
+			}
+		}
+
+		index[sku] = len(details)
+		details = append(details, detail)
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		// The order is locked and checked again, so items are never recorded for a completed or released pick order
+		if _, err := lockPickingOrder(tx, pickOrder); err != nil {
+			if errors.Is(err, errPickOrderInactive) {
+				statusCode = http.StatusConflict
+			}
+			return err
+		}
+
+		if err := tx.Where("pick_order_id = ?", pickOrder.ID).Delete(&models.PickOrderDetail{}).Error; err != nil {
+			return err
+		}
+		if len(details) == 0 {
+			return nil
+		}
+		return tx.Create(&details).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to record picked items", err.Error())
+		return
+	}
+
+	pc.respondWithProgress(c, http.StatusOK, "Picked items recorded successfully", pickOrder.ID)
+}
+
+// ScanPickItem godoc
+// @Summary Scan item barcode
+// @Description Scan a product barcode for a pick order. The barcode is resolved to a SKU, items that are not part of the order or exceed the ordered quantity are rejected, and the per-line progress is returned.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Order ID"
+// @Param request body ScanPickItemRequest true "Scan pick item request"
+// @Success 200 {object} utils.Response{data=ScanPickItemResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/pick-orders/{id}/scan [post]
+func (pc *PickOrderController) ScanPickItem(c *gin.Context) {
+	var req ScanPickItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	barcode := strings.TrimSpace(req.Barcode)
+	if barcode == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid barcode", "barcode must not be empty")
+		return
+	}
+
+	pickOrder, order, ok := pc.findActivePickOrder(c)
+	if !ok {
+		return
+	}
+
+	// Resolve barcode to product
+	product, err := models.FindProductByBarcode(pc.DB, barcode)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Unknown barcode", fmt.Sprintf("no product found with barcode '%s'", barcode))
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resolve barcode", err.Error())
+		return
+	}
+
+	// The scanned product must be part of the order
+	var requirement *models.PickRequirement
+	for _, r := range order.PickRequirements() {
+		if r.Sku == product.Sku {
+			requirement = &r
+			break
+		}
+	}
+	if requirement == nil {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Item is not part of the order", fmt.Sprintf("SKU '%s' (%s) is not in this order", product.Sku, product.Name))
+		return
+	}
+
+	var scanned int
+	var exceeded bool
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the pick order so concurrent scans are counted correctly
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.PickOrder{}, pickOrder.ID).Error; err != nil {
+			return err
+		}
+
+		var detail models.PickOrderDetail
+		err := tx.Where("pick_order_id = ? AND sku = ?", pickOrder.ID, product.Sku).First(&detail).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		if detail.Quantity+req.Quantity > requirement.Quantity {
+			scanned = detail.Quantity
+			exceeded = true
+			return nil
+		}
+
+		if err == gorm.ErrRecordNotFound {
+			detail = models.PickOrderDetail{
+				PickOrderID: pickOrder.ID,
+				Sku:         product.Sku,
+				ProductName: requirement.ProductName,
+				Variant:     requirement.Variant,
+				Quantity:    req.Quantity,
+			}
+			if err := tx.Create(&detail).Error; err != nil {
+				return err
+			}
+		} else {
+			detail.Quantity += req.Quantity
+			if err := tx.Save(&detail).Error; err != nil {
+				return err
+			}
+		}
+
+		scanned = detail.Quantity
+		return nil
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record scanned item", err.Error())
+		return
+	}
+
+	if exceeded {
+		utils.ErrorResponse(c, http.StatusConflict, "Scanned quantity exceeds the ordered quantity", fmt.Sprintf("SKU '%s' already scanned %d of %d", product.Sku, scanned, requirement.Quantity))
+		return
+	}
+
+	// Reload details to report the progress of every line
+	var details []models.PickOrderDetail
+	pc.DB.Where("pick_order_id = ?", pickOrder.ID).Find(&details)
+	lines := models.ComparePickedItems(order.PickRequirements(), details)
+
+	response := ScanPickItemResponse{
+		Sku:         product.Sku,
+		ProductName: product.Name,
+		Scanned:     scanned,
+		Required:    requirement.Quantity,
+		Lines:       lines,
+		Complete:    models.IsPickComplete(lines),
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, fmt.Sprintf("Scanned %s (%d/%d)", product.Sku, scanned, requirement.Quantity), response)
+}
+
+// CompletePickOrder godoc
+// @Summary Complete pick order
+// @Description Complete a pick order. All picked items must match the order details, otherwise the discrepancies are returned. The order moves to "picked".
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Order ID"
+// @Success 200 {object} utils.Response{data=PickOrderProgressResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-orders/{id}/complete [post]
+func (pc *PickOrderController) CompletePickOrder(c *gin.Context) {
+	pickOrder, _, ok := pc.findActivePickOrder(c)
+	if !ok {
+		return
+	}
+
+	message := "Failed to complete pick order"
+	statusCode := http.StatusInternalServerError
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := lockPickingOrder(tx, pickOrder)
+		if err != nil {
+			if errors.Is(err, errPickOrderInactive) {
+				statusCode = http.StatusConflict
+			}
+			return err
+		}
+
+		// Read the picked items again, items recorded after the pick order was loaded count too
+		if err := tx.Where("pick_order_id = ?", pickOrder.ID).Find(&pickOrder.PickOrderDetails).Error; err != nil {
+			return err
+		}
+
+		lines := models.ComparePickedItems(order.PickRequirements(), pickOrder.PickOrderDetails)
+		if !models.IsPickComplete(lines) {
+			var mismatches []string
+			for _, line := range lines {
+				if line.Status != models.PickLineComplete {
+					mismatches = append(mismatches, fmt.Sprintf("%s %s (picked %d of %d)", line.Sku, line.Status, line.Picked, line.Required))
+				}
+			}
+			message = "Picked items do not match the order"
+			statusCode = http.StatusConflict
+			return errors.New(strings.Join(mismatches, "; "))
+		}
+
+		return completePickOrder(tx, pickOrder, order)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, message, err.Error())
+		return
+	}
+
+	pc.respondWithProgress(c, http.StatusOK, "Pick order completed successfully", pickOrder.ID)
+}
+
+// ReleasePickOrder godoc
+// @Summary Release pick order
+// @Description Release a claimed order back to "ready to pick" and discard its pick order.
+// @Tags pick-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Order ID"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-orders/{id}/release [post]
+func (pc *PickOrderController) ReleasePickOrder(c *gin.Context) {
+	pickOrder, _, ok := pc.findActivePickOrder(c)
+	if !ok {
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := lockPickingOrder(tx, pickOrder)
+		if err != nil {
+			if errors.Is(err, errPickOrderInactive) {
+				statusCode = http.StatusConflict
+			}
+			return err
+		}
+
+		previousStatus := order.Status
+		if err := order.TransitionTo(models.OrderStatusReadyToPick, &pickOrder.PickerID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(order).Error; err != nil {
+			return err
+		}
+		if err := models.RecordOrderEvents(tx, models.NewOrderStatusEvent(order, previousStatus, &pickOrder.PickerID, fmt.Sprintf("Released from pick order %d", pickOrder.ID))); err != nil {
+			return err
+		}
+		if err := tx.Where("pick_order_id = ?", pickOrder.ID).Delete(&models.PickOrderDetail{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(pickOrder).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to release pick order", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pick order released successfully", nil)
+}
+
+// errPickOrderInactive is returned by lockPickingOrder when the order is no longer being picked
+var errPickOrderInactive = errors.New("pick order is no longer active")
+
+// lockPickingOrder re-reads the order of a pick order inside the transaction with a row lock, so a cancel or release
+// that lands in between is never overwritten. It fails with errPickOrderInactive when the order has moved on or the
+// pick order was released in the meantime.
+func lockPickingOrder(tx *gorm.DB, pickOrder *models.PickOrder) (*models.Order, error) {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("OrderDetails").First(&order, pickOrder.OrderID).Error; err != nil {
+		return nil, err
+	}
+
+	if order.Status != models.OrderStatusPicking {
+		return nil, fmt.Errorf("%w: order status is '%s'. Order must be in '%s' status", errPickOrderInactive, order.Status, models.OrderStatusPicking)
+	}
+
+	// Released pick orders are soft deleted, the order may have been claimed again since
+	var live int64
+	if err := tx.Model(&models.PickOrder{}).Where("id = ?", pickOrder.ID).Count(&live).Error; err != nil {
+		return nil, err
+	}
+	if live == 0 || order.PickerID == nil || *order.PickerID != pickOrder.PickerID {
+		return nil, fmt.Errorf("%w: the pick order was released", errPickOrderInactive)
+	}
+	return &order, nil
+}
+
+// completePickOrder marks the order as picked by the pick order's picker and takes the picked items
+// out of stock at their product location. Pick order details must be loaded and the order must be locked with
+// lockPickingOrder.
+func completePickOrder(tx *gorm.DB, pickOrder *models.PickOrder, order *models.Order) error {
+	previousStatus := order.Status
+	if err := order.TransitionTo(models.OrderStatusPicked, &pickOrder.PickerID); err != nil {
+		return err
+	}
+
+	if err := tx.Omit(clause.Associations).Save(order).Error; err != nil {
+		return err
+	}
+	if err := models.RecordOrderEvents(tx, models.NewOrderStatusEvent(order, previousStatus, &pickOrder.PickerID, fmt.Sprintf("Picked in pick order %d", pickOrder.ID))); err != nil {
+		return err
+	}
+
+	skus := make([]string, len(pickOrder.PickOrderDetails))
+	for i, detail := range pickOrder.PickOrderDetails {
+		skus[i] = detail.Sku
+	}
+	locations := models.ProductLocations(tx, skus)
+
+	for _, detail := range pickOrder.PickOrderDetails {
+		if detail.Quantity <= 0 {
+			continue
+		}
+		if err := models.RecordStockMovement(tx, &models.StockMovement{
+			Sku:       detail.Sku,
+			Location:  locations[detail.Sku],
+			Type:      models.StockMovementPick,
+			Quantity:  -detail.Quantity,
+			Reference: order.Tracking,
+			UserID:    &pickOrder.PickerID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findActivePickOrder loads the pick order from the path and verifies it belongs to the current picker
+// and that its order is still being picked. It writes the error response and returns false on failure.
+func (pc *PickOrderController) findActivePickOrder(c *gin.Context) (*models.PickOrder, *models.Order, bool) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return nil, nil, false
+	}
+
+	var pickOrder models.PickOrder
+	if err := pc.DB.Preload("Order.OrderDetails").Preload("PickOrderDetails").First(&pickOrder, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Pick order not found", "no pick order found with the specified ID")
+			return nil, nil, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find pick order", err.Error())
+		return nil, nil, false
+	}
+
+	if pickOrder.PickerID != userID {
+		utils.ErrorResponse(c, http.StatusForbidden, "Pick order belongs to another picker", "only the picker who claimed the order can modify this pick order")
+		return nil, nil, false
+	}
+
+	if pickOrder.Order == nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Order not found", "the order of this pick order no longer exists")
+		return nil, nil, false
+	}
+
+	if pickOrder.Order.Status != models.OrderStatusPicking {
+		utils.ErrorResponse(c, http.StatusConflict, "Pick order is no longer active", fmt.Sprintf("order status is '%s'. Order must be in '%s' status", pickOrder.Order.Status, models.OrderStatusPicking))
+		return nil, nil, false
+	}
+
+	return &pickOrder, pickOrder.Order, true
+}
+
+// respondWithProgress loads the pick order and responds with its comparison against the order details
+func (pc *PickOrderController) respondWithProgress(c *gin.Context, statusCode int, message string, pickOrderID uint) {
+	var pickOrder models.PickOrder
+	if err := pc.DB.
+		Preload("Order.OrderDetails").
+		Preload("Order.Picker").
+		Preload("Picker.UserRoles.Role").
+		Preload("PickOrderDetails").
+		First(&pickOrder, pickOrderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Pick order not found", "no pick order found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve pick order", err.Error())
+		return
+	}
+
+	pickOrder.LoadProducts(pc.DB)
+
+	var lines []models.PickComparisonLine
+	if pickOrder.Order != nil {
+		lines = models.ComparePickedItems(pickOrder.Order.PickRequirements(), pickOrder.PickOrderDetails)
+	}
+
+	response := PickOrderProgressResponse{
+		PickOrder: pickOrder.ToPickOrderResponse(),
+		Lines:     lines,
+		Complete:  len(lines) > 0 && models.IsPickComplete(lines),
+	}
+
+	utils.SuccessResponse(c, statusCode, message, response)
+}
+
+// Request/Response structs
+type ClaimPickOrderRequest struct {
+	Tracking     string `json:"tracking" example:"JNE1234567890"`
+	OrderGineeID string `json:"order_ginee_id" example:"2509116GA36VM5"`
+}
+
+type SubmitPickedItemsRequest struct {
+	Items []PickedItemRequest `json:"items" binding:"required,dive"`
+}
+
+type PickedItemRequest struct {
+	Sku      string `json:"sku" binding:"required" example:"PROD001"`
+	Quantity int    `json:"quantity" binding:"required,min=1" example:"2"`
+}
+
+type ScanPickItemRequest struct {
+	Barcode  string `json:"barcode" binding:"required" example:"8991234567890"`
+	Quantity int    `json:"quantity" binding:"omitempty,min=1" example:"1"`
+}
+
+type ScanPickItemResponse struct {
+	Sku         string                      `json:"sku"`
+	ProductName string                      `json:"product_name"`
+	Scanned     int                         `json:"scanned"`
+	Required    int                         `json:"required"`
+	Lines       []models.PickComparisonLine `json:"lines"`
+	Complete    bool                        `json:"complete"`
+}
+
+type PickOrderProgressResponse struct {
+	PickOrder models.PickOrderResponse    `json:"pick_order"`
+	Lines     []models.PickComparisonLine `json:"lines"`
+	Complete  bool                        `json:"complete"`
+}
+
+type PickOrdersListResponse struct {
+	PickOrders []models.PickOrderResponse `json:"pick_orders"`
+	Pagination utils.PaginationResponse   `json:"pagination"`
+}
@@ -0,0 +1,613 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PickWaveController struct {
+	DB *gorm.DB
+}
+
+// NewPickWaveController creates a new pick wave controller
+func NewPickWaveController(db *gorm.DB) *PickWaveController {
+	return &PickWaveController{DB: db}
+}
+
+// CreatePickWave godoc
+// @Summary Create pick wave
+// @Description Group "ready to pick" orders into a wave for batch picking. Either pass order IDs, or a limit to take the orders with the nearest processing limit. All orders move to "picking" for the current picker.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreatePickWaveRequest true "Create pick wave request"
+// @Success 201 {object} utils.Response{data=models.PickWaveResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-waves [post]
+func (wc *PickWaveController) CreatePickWave(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	pickerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreatePickWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if len(req.OrderIDs) == 0 && req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	wave := models.PickWave{
+		Status:   models.PickWaveStatusPicking,
+		PickerID: pickerID,
+	}
+
+	var notReady []string
+	err := wc.DB.Transaction(func(tx *gorm.DB) error {
+		var orders []models.Order
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+		if len(req.OrderIDs) > 0 {
+			if err := query.Where("id IN ?", req.OrderIDs).Find(&orders).Error; err != nil {
+				return err
+			}
+
+			// Every requested order must be available for picking
+			found := make(map[uint]models.Order)
+			for _, order := range orders {
+				found[order.ID] = order
+			}
+			for _, id := range req.OrderIDs {
+				order, exists := found[id]
+				if !exists {
+					notReady = append(notReady, fmt.Sprintf("order %d not found or locked", id))
+				} else if order.Status != models.OrderStatusReadyToPick {
+					notReady = append(notReady, fmt.Sprintf("order %d is '%s'", id, order.Status))
+				}
+			}
+			if len(notReady) > 0 {
+				return nil
+			}
+		} else {
+			query = query.Where("status = ?", models.OrderStatusReadyToPick)
+			if req.Store != "" {
+				query = query.Where("store = ?", req.Store)
+			}
+			if err := query.Order("processing_limit ASC, id ASC").Limit(req.Limit).Find(&orders).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(orders) == 0 {
+			notReady = append(notReady, "no orders are ready to pick")
+			return nil
+		}
+
+		code, err := utils.GenerateWaveCode(tx)
+		if err != nil {
+			return err
+		}
+		wave.Code = code
+		if err := tx.Create(&wave).Error; err != nil {
+			return err
+		}
+
+		for i := range orders {
+			previousStatus := orders[i].Status
+			if err := orders[i].TransitionTo(models.OrderStatusPicking, &pickerID); err != nil {
+				return err
+			}
+			if err := tx.Save(&orders[i]).Error; err != nil {
+				return err
+			}
+			if err := models.RecordOrderEvents(tx, models.NewOrderStatusEvent(&orders[i], previousStatus, &pickerID, "Claimed in wave "+wave.Code)); err != nil {
+				return err
+			}
+
+			pickOrder := models.PickOrder{
+				OrderID:    orders[i].ID,
+				PickerID:   pickerID,
+				PickWaveID: &wave.ID,
+			}
+			if err := tx.Create(&pickOrder).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create pick wave", err.Error())
+		return
+	}
+
+	if len(notReady) > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Orders are not ready to pick", strings.Join(notReady, "; "))
+		return
+	}
+
+	wc.respondWithWave(c, http.StatusCreated, "Pick wave created successfully", wave.ID)
+}
+
+// GetMyPickWaves godoc
+// @Summary Get my pick waves
+// @Description Get the pick waves of the current picker with pagination and optional status filtering.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (picking, completed)"
+// @Success 200 {object} utils.Response{data=PickWavesListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/pick-waves [get]
+func (wc *PickWaveController) GetMyPickWaves(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	status := c.Query("status")
+
+	var waves []models.PickWave
+	var total int64
+
+	query := wc.DB.Model(&models.PickWave{}).Where("picker_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count pick waves", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Preload("PickOrders").Find(&waves).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve pick waves", err.Error())
+		return
+	}
+
+	waveResponses := make([]models.PickWaveResponse, len(waves))
+	for i, wave := range waves {
+		waveResponses[i] = wave.ToPickWaveResponse()
+	}
+
+	response := PickWavesListResponse{
+		PickWaves: waveResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pick waves retrieved successfully", response)
+}
+
+// GetPickWave godoc
+// @Summary Get pick wave by ID
+// @Description Get a pick wave with its pick orders and consolidated pick list.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Wave ID"
+// @Success 200 {object} utils.Response{data=models.PickWaveResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/pick-waves/{id} [get]
+func (wc *PickWaveController) GetPickWave(c *gin.Context) {
+	waveID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid pick wave ID", "pick wave ID must be a valid number")
+		return
+	}
+
+	wc.respondWithWave(c, http.StatusOK, "Pick wave retrieved successfully", uint(waveID))
+}
+
+// GetPickList godoc
+// @Summary Get wave pick list
+// @Description Get the consolidated pick list of a wave, aggregated per SKU and sorted by location. Printable csv lists are queued with POST /api/pick-waves/{id}/pick-list/export.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Wave ID"
+// @Success 200 {object} utils.Response{data=[]models.PickListLine}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/pick-waves/{id}/pick-list [get]
+func (wc *PickWaveController) GetPickList(c *gin.Context) {
+	if format := c.DefaultQuery("format", "json"); format != "json" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid format", "format must be 'json', queue csv exports with POST /api/pick-waves/{id}/pick-list/export")
+		return
+	}
+
+	wave, ok := wc.findWave(c)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pick list retrieved successfully", wave.BuildPickList(wc.DB))
+}
+
+// ExportPickList godoc
+// @Summary Queue wave pick list export
+// @Description Queue a background job that exports the consolidated pick list of a wave as a printable CSV. Poll GET /api/jobs/{id} and download the file from GET /api/jobs/{id}/download.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Wave ID"
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/pick-waves/{id}/pick-list/export [post]
+func (wc *PickWaveController) ExportPickList(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var wave models.PickWave
+	if err := wc.DB.First(&wave, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Pick wave not found", "no pick wave found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find pick wave", err.Error())
+		return
+	}
+
+	queueJob(c, wc.DB, models.JobTypeExportPickList, PickListExportJobParams{PickWaveID: wave.ID}, requesterID, "Pick list export queued")
+}
+
+// RunExportPickListJob writes the pick list of a queued export as the CSV file of the job
+func (wc *PickWaveController) RunExportPickListJob(ctx context.Context, task *jobs.Task) error {
+	var params PickListExportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	var wave models.PickWave
+	if err := wc.DB.
+		Preload("PickOrders.Order.OrderDetails").
+		Preload("PickOrders.PickOrderDetails").
+		First(&wave, params.PickWaveID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return jobs.Permanent(fmt.Errorf("pick wave %d not found", params.PickWaveID))
+		}
+		return err
+	}
+
+	lines := wave.BuildPickList(wc.DB)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"Location", "SKU", "Product Name", "Variant", "Quantity", "Orders"})
+	task.SetTotal(len(lines))
+	for _, line := range lines {
+		writer.Write([]string{
+			line.Location,
+			line.Sku,
+			line.ProductName,
+			line.Variant,
+			strconv.Itoa(line.Quantity),
+			strconv.Itoa(line.Orders),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	task.SetProgress(len(lines))
+	task.SetFile(fmt.Sprintf("pick-list-%s.csv", wave.Code), "text/csv", buffer.Bytes())
+	return nil
+}
+
+// CompletePickWave godoc
+// @Summary Complete pick wave
+// @Description Submit the total quantities picked for a wave. The quantities are distributed to the wave orders by nearest processing limit; fully picked orders move to "picked", the others stay "picking" for individual follow-up.
+// @Tags pick-waves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pick Wave ID"
+// @Param request body SubmitPickedItemsRequest true "Picked items of the wave"
+// @Success 200 {object} utils.Response{data=CompletePickWaveResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/pick-waves/{id}/complete [post]
+func (wc *PickWaveController) CompletePickWave(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	var req SubmitPickedItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	wave, ok := wc.findWave(c)
+	if !ok {
+		return
+	}
+
+	if wave.PickerID != userID {
+		utils.ErrorResponse(c, http.StatusForbidden, "Pick wave belongs to another picker", "only the picker of the wave can complete it")
+		return
+	}
+
+	// Total picked quantity per SKU
+	remaining := make(map[string]int)
+	for _, item := range req.Items {
+		remaining[strings.TrimSpace(item.Sku)] += item.Quantity
+	}
+
+	response := CompletePickWaveResponse{}
+	statusCode := http.StatusInternalServerError
+	err := wc.DB.Transaction(func(tx *gorm.DB) error {
+		// The wave and its orders are locked and read again, so a concurrent completion, cancel or release is
+		// never overwritten
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(wave, wave.ID).Error; err != nil {
+			return err
+		}
+		if wave.Status != models.PickWaveStatusPicking {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("pick wave status is '%s'", wave.Status)
+		}
+
+		// Pick orders released since the wave was loaded are soft deleted and drop out here
+		var pickOrders []models.PickOrder
+		if err := tx.Where("pick_wave_id = ?", wave.ID).Order("id ASC").Find(&pickOrders).Error; err != nil {
+			return err
+		}
+
+		orderIDs := make([]uint, len(pickOrders))
+		for i, pickOrder := range pickOrders {
+			orderIDs[i] = pickOrder.OrderID
+		}
+		var orders []models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("OrderDetails").
+			Where("id IN ?", orderIDs).
+			Order("id ASC").
+			Find(&orders).Error; err != nil {
+			return err
+		}
+		lockedOrders := make(map[uint]*models.Order, len(orders))
+		for i := range orders {
+			lockedOrders[orders[i].ID] = &orders[i]
+		}
+
+		// Orders with the nearest processing limit get their items first
+		for i := range pickOrders {
+			pickOrders[i].Order = lockedOrders[pickOrders[i].OrderID]
+		}
+		sort.SliceStable(pickOrders, func(i, j int) bool {
+			if pickOrders[i].Order == nil || pickOrders[j].Order == nil {
+				return pickOrders[i].ID < pickOrders[j].ID
+			}
+			return pickOrders[i].Order.ProcessingLimit.Before(pickOrders[j].Order.ProcessingLimit)
+		})
+
+		for i := range pickOrders {
+			pickOrder := &pickOrders[i]
+			order := pickOrder.Order
+			if order == nil || order.Status != models.OrderStatusPicking {
+				continue
+			}
+			// The order was released and claimed again by someone else, the new claim owns it now
+			if order.PickerID == nil || *order.PickerID != pickOrder.PickerID {
+				continue
+			}
+
+			// Distribute the picked quantities to this order
+			var details []models.PickOrderDetail
+			for _, requirement := range order.PickRequirements() {
+				quantity := requirement.Quantity
+				if remaining[requirement.Sku] < quantity {
+					quantity = remaining[requirement.Sku]
+				}
+				if quantity <= 0 {
+					continue
+				}
+				remaining[requirement.Sku] -= quantity
+
+				details = append(details, models.PickOrderDetail{
+					PickOrderID: pickOrder.ID,
+					Sku:         requirement.Sku,
+					ProductName: requirement.ProductName,
+					Variant:     requirement.Variant,
+					Quantity:    quantity,
+				})
+			}
+
+			if err := tx.Where("pick_order_id = ?", pickOrder.ID).Delete(&models.PickOrderDetail{}).Error; err != nil {
+				return err
+			}
+			if len(details) > 0 {
+				if err := tx.Create(&details).Error; err != nil {
+					return err
+				}
+			}
+			pickOrder.PickOrderDetails = details
+
+			lines := models.ComparePickedItems(order.PickRequirements(), details)
+			if !models.IsPickComplete(lines) {
+				response.IncompleteOrders = append(response.IncompleteOrders, IncompleteWaveOrder{
+					OrderID:     order.ID,
+					PickOrderID: pickOrder.ID,
+					Tracking:    order.Tracking,
+					Lines:       lines,
+				})
+				continue
+			}
+
+			if err := completePickOrder(tx, pickOrder, order); err != nil {
+				return err
+			}
+			response.CompletedOrderIDs = append(response.CompletedOrderIDs, order.ID)
+		}
+
+		now := time.Now()
+		wave.Status = models.PickWaveStatusCompleted
+		wave.CompletedAt = &now
+		return tx.Omit(clause.Associations).Save(wave).Error
+	})
+	if err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to complete pick wave", err.Error())
+		return
+	}
+
+	// Anything left over was picked more than the wave needs
+	for sku, quantity := range remaining {
+		if quantity > 0 {
+			response.Surplus = append(response.Surplus, PickedItemRequest{Sku: sku, Quantity: quantity})
+		}
+	}
+	sort.Slice(response.Surplus, func(i, j int) bool { return response.Surplus[i].Sku < response.Surplus[j].Sku })
+
+	var reloaded models.PickWave
+	wc.DB.Preload("PickOrders.PickOrderDetails").First(&reloaded, wave.ID)
+	response.PickWave = reloaded.ToPickWaveResponse()
+
+	message := "Pick wave completed successfully"
+	if len(response.IncompleteOrders) > 0 {
+		message = fmt.Sprintf("Pick wave completed with %d incomplete orders", len(response.IncompleteOrders))
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, message, response)
+}
+
+// findWave loads the wave from the path with its pick orders and orders.
+// It writes the error response and returns false on failure.
+func (wc *PickWaveController) findWave(c *gin.Context) (*models.PickWave, bool) {
+	var wave models.PickWave
+	if err := wc.DB.
+		Preload("Picker").
+		Preload("PickOrders.Order.OrderDetails").
+		Preload("PickOrders.PickOrderDetails").
+		First(&wave, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Pick wave not found", "no pick wave found with the specified ID")
+			return nil, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find pick wave", err.Error())
+		return nil, false
+	}
+
+	return &wave, true
+}
+
+// respondWithWave loads the wave and responds with its pick orders and pick list
+func (wc *PickWaveController) respondWithWave(c *gin.Context, statusCode int, message string, waveID uint) {
+	var wave models.PickWave
+	if err := wc.DB.
+		Preload("Picker").
+		Preload("PickOrders.Order.OrderDetails").
+		Preload("PickOrders.PickOrderDetails").
+		First(&wave, waveID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Pick wave not found", "no pick wave found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve pick wave", err.Error())
+		return
+	}
+
+	response := wave.ToPickWaveResponse()
+	response.PickList = wave.BuildPickList(wc.DB)
+
+	utils.SuccessResponse(c, statusCode, message, response)
+}
+
+// Request/Response structs
+type PickListExportJobParams struct {
+	PickWaveID uint `json:"pick_wave_id"`
+}
+
+type CreatePickWaveRequest struct {
+	OrderIDs []uint `json:"order_ids" example:"1,2,3"`
+	Limit    int    `json:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Store    string `json:"store" example:"SP deParcelRibbon"`
+}
+
+type CompletePickWaveResponse struct {
+	PickWave          models.PickWaveResponse `json:"pick_wave"`
+	CompletedOrderIDs []uint                  `json:"completed_order_ids"`
+	IncompleteOrders  []IncompleteWaveOrder   `json:"incomplete_orders"`
+	Surplus           []PickedItemRequest     `json:"surplus"`
+}
+
+type IncompleteWaveOrder struct {
+	OrderID     uint                        `json:"order_id"`
+	PickOrderID uint                        `json:"pick_order_id"`
+	Tracking    string                      `json:"tracking"`
+	Lines       []models.PickComparisonLine `json:"lines"`
+}
+
+type PickWavesListResponse struct {
+	PickWaves  []models.PickWaveResponse `json:"pick_waves"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
@@ -0,0 +1,694 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProductController struct {
+	DB *gorm.DB
+}
+
+// NewProductController creates a new product controller
+func NewProductController(db *gorm.DB) *ProductController {
+	return &ProductController{DB: db}
+}
+
+// GetProducts godoc
+// @Summary Get all products
+// @Description Get all products with pagination and optional search by SKU, barcode or name.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param search query string false "Search by SKU, barcode or name (partial match)"
+// @Success 200 {object} utils.Response{data=ProductsListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/products [get]
+func (pc *ProductController) GetProducts(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	// Parse search parameter
+	search := strings.TrimSpace(c.Query("search"))
+
+	var products []models.Product
+	var total int64
+
+	// Build query with optional search
+	query := pc.DB.Model(&models.Product{})
+
+	if search != "" {
+		// Search by SKU, barcode or name with partial match
+		query = query.Where("sku ILIKE ? OR barcode ILIKE ? OR name ILIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	// Get total count with search filter
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count products", err.Error())
+		return
+	}
+
+	// Get products with pagination, search filter, and order by SKU ascending
+	if err := query.Order("sku ASC").Limit(limit).Offset(offset).Find(&products).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve products", err.Error())
+		return
+	}
+
+	// Load bundle components of the page in one query
+	skus := make([]string, len(products))
+	for i, product := range products {
+		skus[i] = product.Sku
+	}
+	bundles, err := models.FindBundleItems(pc.DB, skus)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve product bundles", err.Error())
+		return
+	}
+
+	// Convert to response format
+	productResponses := make([]models.ProductResponse, len(products))
+	for i, product := range products {
+		product.BundleItems = bundles[product.Sku]
+		productResponses[i] = product.ToProductResponse()
+	}
+
+	response := ProductsListResponse{
+		Products: productResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Products retrieved successfully", response)
+}
+
+// GetProduct godoc
+// @Summary Get product by ID
+// @Description Get product information by ID.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=models.ProductResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/products/{id} [get]
+func (pc *ProductController) GetProduct(c *gin.Context) {
+	productID := c.Param("id")
+
+	var product models.Product
+	if err := pc.DB.First(&product, productID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	if err := product.LoadBundleItems(pc.DB); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve product bundle", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Product retrieved successfully", product.ToProductResponse())
+}
+
+// GetProductByCode godoc
+// @Summary Get product by barcode or SKU
+// @Description Get product information by scanning its barcode, falling back to SKU.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Barcode or SKU"
+// @Success 200 {object} utils.Response{data=models.ProductResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/products/code/{code} [get]
+func (pc *ProductController) GetProductByCode(c *gin.Context) {
+	product, err := models.FindProductByBarcode(pc.DB, strings.TrimSpace(c.Param("code")))
+	if err != nil {
+		if err == models.ErrEmptyBarcode {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid barcode", err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	if err := product.LoadBundleItems(pc.DB); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve product bundle", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Product retrieved successfully", product.ToProductResponse())
+}
+
+// CreateProduct godoc
+// @Summary Create new product
+// @Description Create a new product.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ProductRequest true "Create product request"
+// @Success 201 {object} utils.Response{data=models.ProductResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/products [post]
+func (pc *ProductController) CreateProduct(c *gin.Context) {
+	var req ProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.normalize()
+
+	// Check for duplicate SKU
+	var existingProduct models.Product
+	if err := pc.DB.Unscoped().Where("sku = ?", req.Sku).First(&existingProduct).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid product SKU", "This SKU is already in use")
+		return
+	}
+
+	product := models.Product{}
+	req.applyTo(&product)
+
+	// Create a new product and return the response
+	if err := pc.DB.Create(&product).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create product", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Product created successfully", product.ToProductResponse())
+}
+
+// UpdateProduct godoc
+// @Summary Update product
+// @Description Update product information.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body ProductRequest true "Update product request"
+// @Success 200 {object} utils.Response{data=models.ProductResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/products/{id} [put]
+func (pc *ProductController) UpdateProduct(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req ProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.normalize()
+
+	var product models.Product
+	if err := pc.DB.First(&product, productID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	// Check for duplicate SKU (excluding current product)
+	var existingProduct models.Product
+	if err := pc.DB.Unscoped().Where("sku = ? AND id != ?", req.Sku, productID).First(&existingProduct).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid product SKU", "This SKU is already in use")
+		return
+	}
+
+	previousSku := product.Sku
+	req.applyTo(&product)
+
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&product).Error; err != nil {
+			return err
+		}
+
+		// Bundle definitions refer to products by SKU
+		if product.Sku != previousSku {
+			if err := tx.Model(&models.ProductBundleItem{}).Where("bundle_sku = ?", previousSku).Update("bundle_sku", product.Sku).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.ProductBundleItem{}).Where("component_sku = ?", previousSku).Update("component_sku", product.Sku).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update product", err.Error())
+		return
+	}
+
+	product.LoadBundleItems(pc.DB)
+
+	utils.SuccessResponse(c, http.StatusOK, "Product updated successfully", product.ToProductResponse())
+}
+
+// RemoveProduct godoc
+// @Summary Remove product
+// @Description Remove product by ID.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/products/{id} [delete]
+func (pc *ProductController) RemoveProduct(c *gin.Context) {
+	productID := c.Param("id")
+
+	var product models.Product
+	if err := pc.DB.First(&product, productID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	if err := pc.DB.Delete(&product).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete product", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Product deleted successfully", nil)
+}
+
+// SetProductBundle godoc
+// @Summary Set product bundle components
+// @Description Define the product as a bundle of component products, replacing its current components. Orders created afterwards pick the components instead of the bundle SKU. Send an empty list to turn the bundle back into a plain product. Components must be plain products.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body ProductBundleRequest true "Product bundle request"
+// @Success 200 {object} utils.Response{data=models.ProductResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/products/{id}/bundle [put]
+func (pc *ProductController) SetProductBundle(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req ProductBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var product models.Product
+	if err := pc.DB.First(&product, productID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	// Merge components that share the same SKU
+	var items []models.ProductBundleItem
+	index := make(map[string]int)
+	for _, component := range req.Components {
+		sku := strings.TrimSpace(component.Sku)
+		if i, exists := index[sku]; exists {
+			items[i].Quantity += component.Quantity
+			continue
+		}
+
+		index[sku] = len(items)
+		items = append(items, models.ProductBundleItem{
+			BundleSku:    product.Sku,
+			ComponentSku: sku,
+			Quantity:     component.Quantity,
+		})
+	}
+
+	if len(items) > 0 {
+		if _, exists := index[product.Sku]; exists {
+			utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Invalid bundle component", "a bundle cannot contain itself")
+			return
+		}
+
+		// A product used as a component cannot become a bundle
+		var parent models.ProductBundleItem
+		if err := pc.DB.Where("component_sku = ?", product.Sku).First(&parent).Error; err == nil {
+			utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Invalid bundle", fmt.Sprintf("product is a component of bundle '%s', bundles cannot be nested", parent.BundleSku))
+			return
+		}
+
+		componentSkus := make([]string, len(items))
+		for i, item := range items {
+			componentSkus[i] = item.ComponentSku
+		}
+
+		var found []models.Product
+		if err := pc.DB.Where("sku IN ?", componentSkus).Find(&found).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find components", err.Error())
+			return
+		}
+		existing := make(map[string]bool)
+		for _, component := range found {
+			existing[component.Sku] = true
+		}
+
+		bundles, err := models.FindBundleItems(pc.DB, componentSkus)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find components", err.Error())
+			return
+		}
+
+		for _, sku := range componentSkus {
+			if !existing[sku] {
+				utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Invalid bundle component", fmt.Sprintf("no product found with SKU '%s'", sku))
+				return
+			}
+			if len(bundles[sku]) > 0 {
+				utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Invalid bundle component", fmt.Sprintf("product '%s' is a bundle, bundles cannot be nested", sku))
+				return
+			}
+		}
+	}
+
+	if err := pc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_sku = ?", product.Sku).Delete(&models.ProductBundleItem{}).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Create(&items).Error
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update product bundle", err.Error())
+		return
+	}
+
+	if err := product.LoadBundleItems(pc.DB); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve product bundle", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Product bundle updated successfully", product.ToProductResponse())
+}
+
+// ImportProducts godoc
+// @Summary Import products
+// @Description Queue a background job that imports products from a .csv or .xlsx file with a header row (sku, name, image, variant, location, barcode). The file is read before the job is queued. Rows are upserted by SKU: new SKUs are created, existing SKUs are updated with the non-empty cells of the row, and deleted products are restored. Poll GET /api/jobs/{id}; the result of the job is an ImportProductsResponse.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Product file (.csv or .xlsx)"
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/products/import [post]
+func (pc *ProductController) ImportProducts(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	importerID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is required", err.Error())
+		return
+	}
+
+	rows, err := utils.ReadSpreadsheet(fileHeader)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file", err.Error())
+		return
+	}
+
+	if len(rows) < 2 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is empty", "the file must contain a header row and at least one product row")
+		return
+	}
+
+	// Map header names to column indexes
+	columns := make(map[string]int)
+	for i, header := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	for _, required := range []string{"sku", "name"} {
+		if _, exists := columns[required]; !exists {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid file header", fmt.Sprintf("column '%s' is required", required))
+			return
+		}
+	}
+
+	cell := func(row []string, name string) string {
+		i, exists := columns[name]
+		if !exists || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var params ProductImportJobParams
+	for i, row := range rows[1:] {
+		req := ProductRequest{
+			Sku:      cell(row, "sku"),
+			Name:     cell(row, "name"),
+			Image:    cell(row, "image"),
+			Variant:  cell(row, "variant"),
+			Location: cell(row, "location"),
+			Barcode:  cell(row, "barcode"),
+		}
+		req.normalize()
+
+		// Skip blank lines
+		if req.Sku == "" && req.Name == "" && req.Barcode == "" {
+			continue
+		}
+
+		params.Rows = append(params.Rows, ProductImportRow{
+			Row:     i + 2, // 1-based, after the header row
+			Product: req,
+		})
+	}
+
+	if len(params.Rows) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is empty", "the file must contain a header row and at least one product row")
+		return
+	}
+
+	queueJob(c, pc.DB, models.JobTypeImportProducts, params, importerID, "Product import queued")
+}
+
+// RunImportProductsJob upserts the rows of a queued product import. The report is the result of the job.
+func (pc *ProductController) RunImportProductsJob(ctx context.Context, task *jobs.Task) error {
+	var params ProductImportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	task.SetTotal(len(params.Rows))
+
+	createdRows := []ImportedProductRow{}
+	updatedRows := []ImportedProductRow{}
+	failedRows := []FailedProductRow{}
+
+	for i, row := range params.Rows {
+		// A cancelled import stops between rows and reports the rows it imported
+		if ctx.Err() != nil {
+			break
+		}
+		task.SetProgress(i)
+
+		rowNumber := row.Row
+		req := row.Product
+
+		if req.Sku == "" {
+			failedRows = append(failedRows, FailedProductRow{Row: rowNumber, Sku: req.Sku, Error: "sku is required"})
+			continue
+		}
+
+		var product models.Product
+		err := pc.DB.Unscoped().Where("sku = ?", req.Sku).First(&product).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			failedRows = append(failedRows, FailedProductRow{Row: rowNumber, Sku: req.Sku, Error: err.Error()})
+			continue
+		}
+
+		if err == gorm.ErrRecordNotFound {
+			if req.Name == "" {
+				failedRows = append(failedRows, FailedProductRow{Row: rowNumber, Sku: req.Sku, Error: "name is required for new products"})
+				continue
+			}
+
+			product = models.Product{}
+			req.applyTo(&product)
+			if err := pc.DB.Create(&product).Error; err != nil {
+				failedRows = append(failedRows, FailedProductRow{Row: rowNumber, Sku: req.Sku, Error: err.Error()})
+				continue
+			}
+			createdRows = append(createdRows, ImportedProductRow{Row: rowNumber, Product: product.ToProductResponse()})
+			continue
+		}
+
+		// Only the filled cells overwrite an existing product
+		if req.Name != "" {
+			product.Name = req.Name
+		}
+		if req.Image != "" {
+			product.Image = req.Image
+		}
+		if req.Variant != "" {
+			product.Variant = req.Variant
+		}
+		if req.Location != "" {
+			product.Location = req.Location
+		}
+		if req.Barcode != "" {
+			product.Barcode = req.Barcode
+		}
+		product.DeletedAt = gorm.DeletedAt{}
+
+		if err := pc.DB.Unscoped().Save(&product).Error; err != nil {
+			failedRows = append(failedRows, FailedProductRow{Row: rowNumber, Sku: req.Sku, Error: err.Error()})
+			continue
+		}
+		updatedRows = append(updatedRows, ImportedProductRow{Row: rowNumber, Product: product.ToProductResponse()})
+	}
+	task.SetProgress(len(createdRows) + len(updatedRows) + len(failedRows))
+
+	response := ImportProductsResponse{
+		Summary: ImportProductsSummary{
+			Total:   len(params.Rows),
+			Created: len(createdRows),
+			Updated: len(updatedRows),
+			Failed:  len(failedRows),
+		},
+		CreatedRows: createdRows,
+		UpdatedRows: updatedRows,
+		FailedRows:  failedRows,
+	}
+
+	if err := task.SetResult(response); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(createdRows) == 0 && len(updatedRows) == 0 {
+		return jobs.Permanent(errors.New("No products could be imported"))
+	}
+	return nil
+}
+
+// normalize trims the request fields
+func (req *ProductRequest) normalize() {
+	req.Sku = strings.TrimSpace(req.Sku)
+	req.Name = strings.TrimSpace(req.Name)
+	req.Image = strings.TrimSpace(req.Image)
+	req.Variant = strings.TrimSpace(req.Variant)
+	req.Location = strings.TrimSpace(req.Location)
+	req.Barcode = strings.TrimSpace(req.Barcode)
+}
+
+// applyTo copies the request fields to the product
+func (req *ProductRequest) applyTo(product *models.Product) {
+	product.Sku = req.Sku
+	product.Name = req.Name
+	product.Image = req.Image
+	product.Variant = req.Variant
+	product.Location = req.Location
+	product.Barcode = req.Barcode
+}
+
+// Request/Response structs
+type ProductsListResponse struct {
+	Products   []models.ProductResponse `json:"products"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
+
+type ProductRequest struct {
+	Sku      string `json:"sku" binding:"required" example:"SKU-001"`
+	Name     string `json:"name" binding:"required" example:"Ribbon Satin 1 inch"`
+	Image    string `json:"image" example:"https://example.com/ribbon.jpg"`
+	Variant  string `json:"variant" example:"Red"`
+	Location string `json:"location" example:"A-01-02"`
+	Barcode  string `json:"barcode" example:"8991234567890"`
+}
+
+type ProductBundleRequest struct {
+	Components []ProductBundleComponentRequest `json:"components" binding:"omitempty,dive"`
+}
+
+type ProductBundleComponentRequest struct {
+	Sku      string `json:"sku" binding:"required" example:"SKU-002"`
+	Quantity int    `json:"quantity" binding:"required,min=1" example:"2"`
+}
+
+type ProductImportJobParams struct {
+	Rows []ProductImportRow `json:"rows"`
+}
+
+type ProductImportRow struct {
+	Row     int            `json:"row"`
+	Product ProductRequest `json:"product"`
+}
+
+type ImportProductsResponse struct {
+	Summary     ImportProductsSummary `json:"summary"`
+	CreatedRows []ImportedProductRow  `json:"created_rows"`
+	UpdatedRows []ImportedProductRow  `json:"updated_rows"`
+	FailedRows  []FailedProductRow    `json:"failed_rows"`
+}
+
+type ImportProductsSummary struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Failed  int `json:"failed"`
+}
+
+type ImportedProductRow struct {
+	Row     int                    `json:"row"`
+	Product models.ProductResponse `json:"product"`
+}
+
+type FailedProductRow struct {
+	Row   int    `json:"row"`
+	Sku   string `json:"sku"`
+	Error string `json:"error"`
+}
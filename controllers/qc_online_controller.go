@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type QcOnlineController struct {
+	DB *gorm.DB
+}
+
+// NewQcOnlineController creates a new QC online controller
+func NewQcOnlineController(db *gorm.DB) *QcOnlineController {
+	return &QcOnlineController{DB: db}
+}
+
+// CreateQcOnline godoc
+// @Summary Create QC online
+// @Description Scan a tracking number at the QC online station and record the boxes used. The order must be picked, not cancelled and not QC'd yet. The order moves to "packed".
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateQcRequest true "Create QC online request"
+// @Success 201 {object} utils.Response{data=models.QcOnlineResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/qc-online [post]
+func (qoc *QcOnlineController) CreateQcOnline(c *gin.Context) {
+	qoc.station().createRecord(c)
+}
+
+// GetQcOnlines godoc
+// @Summary Get all QC online
+// @Description Get list of QC online records with pagination, optional date range filtering and search by tracking.
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Param search query string false "Search by tracking number"
+// @Success 200 {object} utils.Response{data=QcOnlinesListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-online [get]
+func (qoc *QcOnlineController) GetQcOnlines(c *gin.Context) {
+	qoc.station().listRecords(c)
+}
+
+// GetQcOnline godoc
+// @Summary Get QC online by ID
+// @Description Get a QC online record with its boxes and order.
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "QC Online ID"
+// @Success 200 {object} utils.Response{data=models.QcOnlineResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/qc-online/{id} [get]
+func (qoc *QcOnlineController) GetQcOnline(c *gin.Context) {
+	qoc.station().getRecord(c)
+}
+
+// UpdateQcOnline godoc
+// @Summary Update QC online
+// @Description Replace the boxes recorded for a QC online record. Only allowed while the order is still "packed".
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "QC Online ID"
+// @Param request body UpdateQcRequest true "Update QC online request"
+// @Success 200 {object} utils.Response{data=models.QcOnlineResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/qc-online/{id} [put]
+func (qoc *QcOnlineController) UpdateQcOnline(c *gin.Context) {
+	qoc.station().updateRecord(c)
+}
+
+// GetQcOnlineQueue godoc
+// @Summary Get QC online queue
+// @Description Get picked orders of non-ribbon stores waiting for the QC online station, sorted by processing limit.
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param store query string false "Filter by order store"
+// @Success 200 {object} utils.Response{data=OrdersListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-online/queue [get]
+func (qoc *QcOnlineController) GetQcOnlineQueue(c *gin.Context) {
+	respondWithQcQueue(c, qoc.DB, false)
+}
+
+// GetQcOnlineStatistics godoc
+// @Summary Get QC online statistics
+// @Description Get the number of QC'd and pending orders per non-ribbon store, with optional date range filtering.
+// @Tags qc-online
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=QcStatisticsResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-online/statistics [get]
+func (qoc *QcOnlineController) GetQcOnlineStatistics(c *gin.Context) {
+	respondWithQcStatistics(c, qoc.DB, false)
+}
+
+// station describes the QC online records to the handlers shared by the QC stations
+func (qoc *QcOnlineController) station() *qcStation {
+	return &qcStation{
+		db:     qoc.DB,
+		name:   "QC online",
+		ribbon: false,
+		model:  &models.QcOnline{},
+		create: func(tx *gorm.DB, tracking string, operatorID uint, details []QcBoxRequest) (uint, error) {
+			qcOnline := models.QcOnline{
+				Tracking: tracking,
+				UserID:   &operatorID,
+			}
+			for _, detail := range details {
+				qcOnline.QcOnlineDetails = append(qcOnline.QcOnlineDetails, models.QcOnlineDetail{
+					BoxID:    detail.BoxID,
+					Quantity: detail.Quantity,
+				})
+			}
+			err := tx.Create(&qcOnline).Error
+			return qcOnline.ID, err
+		},
+		replaceDetails: func(tx *gorm.DB, qcOnlineID uint, details []QcBoxRequest) error {
+			if err := tx.Where("qc_online_id = ?", qcOnlineID).Delete(&models.QcOnlineDetail{}).Error; err != nil {
+				return err
+			}
+			for _, detail := range details {
+				if err := tx.Create(&models.QcOnlineDetail{
+					QcOnlineID: qcOnlineID,
+					BoxID:      detail.BoxID,
+					Quantity:   detail.Quantity,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		list: func(query *gorm.DB, pagination utils.PaginationResponse) (interface{}, error) {
+			var qcOnlines []models.QcOnline
+			if err := query.
+				Preload("QcOnlineDetails.Box").
+				Preload("User").
+				Find(&qcOnlines).Error; err != nil {
+				return nil, err
+			}
+
+			trackings := make([]string, len(qcOnlines))
+			for i, record := range qcOnlines {
+				trackings[i] = record.Tracking
+			}
+			orders, err := qcOrdersByTracking(qoc.DB, trackings)
+			if err != nil {
+				return nil, err
+			}
+			for i := range qcOnlines {
+				qcOnlines[i].Order = orders[qcOnlines[i].Tracking]
+			}
+
+			return QcOnlinesListResponse{
+				QcOnlines:  models.ToQcOnlineResponses(qcOnlines),
+				Pagination: pagination,
+			}, nil
+		},
+		load: func(qcOnlineID uint) (interface{}, error) {
+			var qcOnline models.QcOnline
+			if err := qoc.DB.
+				Preload("QcOnlineDetails.Box").
+				Preload("User").
+				First(&qcOnline, qcOnlineID).Error; err != nil {
+				return nil, err
+			}
+			qcOnline.Order, _ = models.ResolveOrder(qoc.DB, qcOnline.Tracking, "")
+			return qcOnline.ToQcOnlineResponse(), nil
+		},
+	}
+}
+
+// Request/Response structs
+type QcOnlinesListResponse struct {
+	QcOnlines  []models.QcOnlineResponse `json:"qc_onlines"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type QcRibbonController struct {
+	DB *gorm.DB
+}
+
+// NewQcRibbonController creates a new QC ribbon controller
+func NewQcRibbonController(db *gorm.DB) *QcRibbonController {
+	return &QcRibbonController{DB: db}
+}
+
+// CreateQcRibbon godoc
+// @Summary Create QC ribbon
+// @Description Scan a tracking number at the QC ribbon station and record the boxes used. The order must be picked, not cancelled and not QC'd yet. The order moves to "packed".
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateQcRequest true "Create QC ribbon request"
+// @Success 201 {object} utils.Response{data=models.QcRibbonResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/qc-ribbon [post]
+func (qrc *QcRibbonController) CreateQcRibbon(c *gin.Context) {
+	qrc.station().createRecord(c)
+}
+
+// GetQcRibbons godoc
+// @Summary Get all QC ribbon
+// @Description Get list of QC ribbon records with pagination, optional date range filtering and search by tracking.
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Param search query string false "Search by tracking number"
+// @Success 200 {object} utils.Response{data=QcRibbonsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-ribbon [get]
+func (qrc *QcRibbonController) GetQcRibbons(c *gin.Context) {
+	qrc.station().listRecords(c)
+}
+
+// GetQcRibbon godoc
+// @Summary Get QC ribbon by ID
+// @Description Get a QC ribbon record with its boxes and order.
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "QC Ribbon ID"
+// @Success 200 {object} utils.Response{data=models.QcRibbonResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/qc-ribbon/{id} [get]
+func (qrc *QcRibbonController) GetQcRibbon(c *gin.Context) {
+	qrc.station().getRecord(c)
+}
+
+// UpdateQcRibbon godoc
+// @Summary Update QC ribbon
+// @Description Replace the boxes recorded for a QC ribbon record. Only allowed while the order is still "packed".
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "QC Ribbon ID"
+// @Param request body UpdateQcRequest true "Update QC ribbon request"
+// @Success 200 {object} utils.Response{data=models.QcRibbonResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/qc-ribbon/{id} [put]
+func (qrc *QcRibbonController) UpdateQcRibbon(c *gin.Context) {
+	qrc.station().updateRecord(c)
+}
+
+// GetQcRibbonQueue godoc
+// @Summary Get QC ribbon queue
+// @Description Get picked orders of ribbon stores waiting for the QC ribbon station, sorted by processing limit.
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param store query string false "Filter by order store"
+// @Success 200 {object} utils.Response{data=OrdersListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-ribbon/queue [get]
+func (qrc *QcRibbonController) GetQcRibbonQueue(c *gin.Context) {
+	respondWithQcQueue(c, qrc.DB, true)
+}
+
+// GetQcRibbonStatistics godoc
+// @Summary Get QC ribbon statistics
+// @Description Get the number of QC'd and pending orders per ribbon store, with optional date range filtering.
+// @Tags qc-ribbon
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=QcStatisticsResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/qc-ribbon/statistics [get]
+func (qrc *QcRibbonController) GetQcRibbonStatistics(c *gin.Context) {
+	respondWithQcStatistics(c, qrc.DB, true)
+}
+
+// station describes the QC ribbon records to the handlers shared by the QC stations
+func (qrc *QcRibbonController) station() *qcStation {
+	return &qcStation{
+		db:     qrc.DB,
+		name:   "QC ribbon",
+		ribbon: true,
+		model:  &models.QcRibbon{},
+		create: func(tx *gorm.DB, tracking string, operatorID uint, details []QcBoxRequest) (uint, error) {
+			qcRibbon := models.QcRibbon{
+				Tracking: tracking,
+				UserID:   &operatorID,
+			}
+			for _, detail := range details {
+				qcRibbon.QcRibbonDetails = append(qcRibbon.QcRibbonDetails, models.QcRibbonDetail{
+					BoxID:    detail.BoxID,
+					Quantity: detail.Quantity,
+				})
+			}
+			err := tx.Create(&qcRibbon).Error
+			return qcRibbon.ID, err
+		},
+		replaceDetails: func(tx *gorm.DB, qcRibbonID uint, details []QcBoxRequest) error {
+			if err := tx.Where("qc_ribbon_id = ?", qcRibbonID).Delete(&models.QcRibbonDetail{}).Error; err != nil {
+				return err
+			}
+			for _, detail := range details {
+				if err := tx.Create(&models.QcRibbonDetail{
+					QcRibbonID: qcRibbonID,
+					BoxID:      detail.BoxID,
+					Quantity:   detail.Quantity,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		list: func(query *gorm.DB, pagination utils.PaginationResponse) (interface{}, error) {
+			var qcRibbons []models.QcRibbon
+			if err := query.
+				Preload("QcRibbonDetails.Box").
+				Preload("User").
+				Find(&qcRibbons).Error; err != nil {
+				return nil, err
+			}
+
+			trackings := make([]string, len(qcRibbons))
+			for i, record := range qcRibbons {
+				trackings[i] = record.Tracking
+			}
+			orders, err := qcOrdersByTracking(qrc.DB, trackings)
+			if err != nil {
+				return nil, err
+			}
+			for i := range qcRibbons {
+				qcRibbons[i].Order = orders[qcRibbons[i].Tracking]
+			}
+
+			return QcRibbonsListResponse{
+				QcRibbons:  models.ToQcRibbonResponses(qcRibbons),
+				Pagination: pagination,
+			}, nil
+		},
+		load: func(qcRibbonID uint) (interface{}, error) {
+			var qcRibbon models.QcRibbon
+			if err := qrc.DB.
+				Preload("QcRibbonDetails.Box").
+				Preload("User").
+				First(&qcRibbon, qcRibbonID).Error; err != nil {
+				return nil, err
+			}
+			qcRibbon.Order, _ = models.ResolveOrder(qrc.DB, qcRibbon.Tracking, "")
+			return qcRibbon.ToQcRibbonResponse(), nil
+		},
+	}
+}
+
+// Request/Response structs
+type QcRibbonsListResponse struct {
+	QcRibbons  []models.QcRibbonResponse `json:"qc_ribbons"`
+	Pagination utils.PaginationResponse  `json:"pagination"`
+}
@@ -0,0 +1,488 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// qcStation is what differs between the QC online and the QC ribbon station. Both stations share their
+// handlers, the station controllers only describe their records and the stores they QC.
+type qcStation struct {
+	db     *gorm.DB
+	name   string      // name of the station in messages, e.g. "QC online"
+	ribbon bool        // whether the station QCs the orders of the ribbon stores
+	model  interface{} // model of the QC records, used for counting and looking them up
+
+	// create saves the QC record of a tracking number with its boxes and returns its ID
+	create func(tx *gorm.DB, tracking string, operatorID uint, details []QcBoxRequest) (uint, error)
+	// replaceDetails replaces the boxes of a QC record
+	replaceDetails func(tx *gorm.DB, recordID uint, details []QcBoxRequest) error
+	// list loads a page of QC records with their boxes, operator and order into the list response
+	list func(query *gorm.DB, pagination utils.PaginationResponse) (interface{}, error)
+	// load loads a QC record with its boxes, operator and order as a response
+	load func(recordID uint) (interface{}, error)
+}
+
+// createRecord scans a tracking number at the station, records the boxes used and packs the order
+func (s *qcStation) createRecord(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	operatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateQcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Tracking = strings.TrimSpace(req.Tracking)
+
+	details, err := buildQcBoxDetails(s.db, req.Details)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid boxes", err.Error())
+		return
+	}
+
+	var recordID uint
+	statusCode := http.StatusInternalServerError
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		order, status, err := lockOrderForQc(tx, req.Tracking, s.ribbon)
+		if err != nil {
+			statusCode = status
+			return err
+		}
+
+		if recordID, err = s.create(tx, req.Tracking, operatorID, details); err != nil {
+			return err
+		}
+		return packOrderAfterQc(tx, order, operatorID, fmt.Sprintf("%s %d", s.name, recordID))
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to create "+s.name, err.Error())
+		return
+	}
+
+	s.respondWithRecord(c, http.StatusCreated, s.name+" created successfully", recordID)
+}
+
+// listRecords responds with a page of the QC records of the station, with optional date range
+// filtering and search by tracking
+func (s *qcStation) listRecords(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	search := strings.TrimSpace(c.Query("search"))
+
+	var total int64
+
+	query := s.db.Model(s.model)
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if search != "" {
+		query = query.Where("tracking ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count "+s.name, err.Error())
+		return
+	}
+
+	response, err := s.list(query.Order("id DESC").Limit(limit).Offset(offset), utils.PaginationResponse{
+		Page:  page,
+		Limit: limit,
+		Total: int(total),
+	})
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve "+s.name, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, s.name+" retrieved successfully", response)
+}
+
+// getRecord responds with a QC record of the station with its boxes and order
+func (s *qcStation) getRecord(c *gin.Context) {
+	recordID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+s.name+" ID", s.name+" ID must be a valid number")
+		return
+	}
+
+	s.respondWithRecord(c, http.StatusOK, s.name+" retrieved successfully", uint(recordID))
+}
+
+// updateRecord replaces the boxes of a QC record while its order is still packed
+func (s *qcStation) updateRecord(c *gin.Context) {
+	var req UpdateQcRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var record struct {
+		ID       uint
+		Tracking string
+	}
+	if err := s.db.Model(s.model).Select("id", "tracking").Where("id = ?", c.Param("id")).Take(&record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, s.name+" not found", "no "+s.name+" found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find "+s.name, err.Error())
+		return
+	}
+
+	details, err := buildQcBoxDetails(s.db, req.Details)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid boxes", err.Error())
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so it cannot move on to outbound while its boxes are replaced
+		var order models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tracking = ?", record.Tracking).First(&order).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if err == nil && order.Status != models.OrderStatusPacked {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("order status is '%s'. Boxes can only be changed while the order is '%s'", order.Status, models.OrderStatusPacked)
+		}
+
+		if err := s.replaceDetails(tx, record.ID, details); err != nil {
+			return err
+		}
+		return tx.Model(s.model).Where("id = ?", record.ID).Update("updated_at", time.Now()).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to update "+s.name, err.Error())
+		return
+	}
+
+	s.respondWithRecord(c, http.StatusOK, s.name+" updated successfully", record.ID)
+}
+
+// respondWithRecord loads the QC record with its boxes, operator and order
+func (s *qcStation) respondWithRecord(c *gin.Context, statusCode int, message string, recordID uint) {
+	response, err := s.load(recordID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, s.name+" not found", "no "+s.name+" found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve "+s.name, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, statusCode, message, response)
+}
+
+// qcOrdersByTracking loads the orders of the QC records of a list page in one query, keyed by tracking
+func qcOrdersByTracking(db *gorm.DB, trackings []string) (map[string]*models.Order, error) {
+	orders := make(map[string]*models.Order)
+	if len(trackings) == 0 {
+		return orders, nil
+	}
+
+	var found []models.Order
+	if err := db.Preload("OrderDetails").
+		Preload("Picker").
+		Where("tracking IN ?", trackings).
+		Find(&found).Error; err != nil {
+		return nil, err
+	}
+	for i := range found {
+		orders[found[i].Tracking] = &found[i]
+	}
+
+	return orders, nil
+}
+
+// lockOrderForQc locks the order of a tracking number and verifies it is ready for QC at the online
+// or ribbon station. It must run inside the transaction that records the QC, so a double scan waits
+// for the first one and is refused. It returns the HTTP status to respond with when the order cannot
+// be QC'd.
+func lockOrderForQc(tx *gorm.DB, tracking string, ribbon bool) (*models.Order, int, error) {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tracking = ?", tracking).First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, fmt.Errorf("no order found with tracking '%s'", tracking)
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if order.Status == models.OrderStatusCancelled {
+		return nil, http.StatusConflict, fmt.Errorf("order with tracking '%s' has been cancelled", tracking)
+	}
+
+	// A parcel is QC'd once, either at the online or the ribbon station
+	var qcOnlineCount, qcRibbonCount int64
+	if err := tx.Model(&models.QcOnline{}).Where("tracking = ?", tracking).Count(&qcOnlineCount).Error; err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := tx.Model(&models.QcRibbon{}).Where("tracking = ?", tracking).Count(&qcRibbonCount).Error; err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if qcOnlineCount > 0 || qcRibbonCount > 0 {
+		return nil, http.StatusConflict, fmt.Errorf("order with tracking '%s' has already been QC'd", tracking)
+	}
+
+	if order.Status != models.OrderStatusPicked {
+		return nil, http.StatusConflict, fmt.Errorf("order status is '%s'. Order must be in '%s' status", order.Status, models.OrderStatusPicked)
+	}
+
+	// Orders of ribbon stores go to the ribbon station, all others to the online station
+	ribbonStores, err := models.GetRibbonStores(tx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	isRibbon := models.IsRibbonOrderStore(ribbonStores, order.Store)
+	if ribbon && !isRibbon {
+		return nil, http.StatusConflict, fmt.Errorf("order of store '%s' must be QC'd at the online station", order.Store)
+	}
+	if !ribbon && isRibbon {
+		return nil, http.StatusConflict, fmt.Errorf("order of store '%s' must be QC'd at the ribbon station", order.Store)
+	}
+
+	return &order, http.StatusOK, nil
+}
+
+// buildQcBoxDetails validates the boxes used at a QC station and merges duplicate boxes
+func buildQcBoxDetails(db *gorm.DB, items []QcBoxRequest) ([]QcBoxRequest, error) {
+	var details []QcBoxRequest
+	index := make(map[uint]int)
+	var boxIDs []uint
+
+	for _, item := range items {
+		if i, exists := index[item.BoxID]; exists {
+			details[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.BoxID] = len(details)
+		details = append(details, item)
+		boxIDs = append(boxIDs, item.BoxID)
+	}
+
+	var count int64
+	if err := db.Model(&models.Box{}).Where("id IN ?", boxIDs).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(boxIDs) {
+		return nil, fmt.Errorf("one or more boxes do not exist")
+	}
+
+	return details, nil
+}
+
+// packOrderAfterQc moves a picked order through QC to packed, recording both steps with the note
+func packOrderAfterQc(tx *gorm.DB, order *models.Order, operatorID uint, note string) error {
+	pickedStatus := order.Status
+	if err := order.TransitionTo(models.OrderStatusQC, &operatorID); err != nil {
+		return err
+	}
+	qcEvent := models.NewOrderStatusEvent(order, pickedStatus, &operatorID, note)
+	if err := order.TransitionTo(models.OrderStatusPacked, &operatorID); err != nil {
+		return err
+	}
+	packedEvent := models.NewOrderStatusEvent(order, models.OrderStatusQC, &operatorID, note)
+
+	if err := tx.Omit(clause.Associations).Save(order).Error; err != nil {
+		return err
+	}
+	return models.RecordOrderEvents(tx, qcEvent, packedEvent)
+}
+
+// storeScopeCondition returns the SQL condition selecting orders of the ribbon stores, or of all other stores
+func storeScopeCondition(db *gorm.DB, column string, ribbon bool) (string, []interface{}, error) {
+	ribbonStores, err := models.GetRibbonStores(db)
+	if err != nil {
+		return "", nil, err
+	}
+
+	condition, args := models.RibbonStoreCondition(ribbonStores, column)
+	if !ribbon {
+		condition = "NOT " + condition
+	}
+
+	return condition, args, nil
+}
+
+// respondWithQcQueue responds with the picked orders waiting for the online or ribbon QC station,
+// sorted by processing limit
+func respondWithQcQueue(c *gin.Context, db *gorm.DB, ribbon bool) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	condition, args, err := storeScopeCondition(db, "store", ribbon)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to load ribbon stores", err.Error())
+		return
+	}
+
+	var orders []models.Order
+	var total int64
+
+	query := db.Model(&models.Order{}).
+		Where("status = ?", models.OrderStatusPicked).
+		Where(condition, args...)
+
+	if store := c.Query("store"); store != "" {
+		query = query.Where("store = ?", store)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count QC queue", err.Error())
+		return
+	}
+
+	if err := query.Order("processing_limit ASC, id ASC").Limit(limit).Offset(offset).
+		Preload("OrderDetails").
+		Preload("Picker").
+		Find(&orders).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve QC queue", err.Error())
+		return
+	}
+
+	orderResponses := make([]models.OrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = order.ToOrderResponse()
+	}
+
+	response := OrdersListResponse{
+		Orders: orderResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "QC queue retrieved successfully", response)
+}
+
+// respondWithQcStatistics responds with the number of QC'd and pending orders per store for the
+// online or ribbon stores. QC'd orders are counted from both stations, so the numbers follow the
+// store of the order rather than the station that happened to scan it.
+func respondWithQcStatistics(c *gin.Context, db *gorm.DB, ribbon bool) {
+	condition, args, err := storeScopeCondition(db, "orders.store", ribbon)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to load ribbon stores", err.Error())
+		return
+	}
+
+	statistics := make(map[string]*QcStoreStatistic)
+	var stores []string
+	statistic := func(store string) *QcStoreStatistic {
+		if _, exists := statistics[store]; !exists {
+			statistics[store] = &QcStoreStatistic{Store: store}
+			stores = append(stores, store)
+		}
+		return statistics[store]
+	}
+
+	type storeCount struct {
+		Store string
+		Total int64
+	}
+
+	for _, table := range []string{"qc_onlines", "qc_ribbons"} {
+		query := db.Table(table).
+			Select("orders.store AS store, COUNT(*) AS total").
+			Joins("JOIN orders ON orders.tracking = "+table+".tracking AND orders.deleted_at IS NULL").
+			Where(table+".deleted_at IS NULL").
+			Where(condition, args...)
+
+		query, ok := applyDateRange(c, query, table+".created_at")
+		if !ok {
+			return
+		}
+
+		var counts []storeCount
+		if err := query.Group("orders.store").Scan(&counts).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve QC statistics", err.Error())
+			return
+		}
+		for _, count := range counts {
+			statistic(count.Store).Checked += count.Total
+		}
+	}
+
+	var pending []storeCount
+	if err := db.Model(&models.Order{}).
+		Select("orders.store AS store, COUNT(*) AS total").
+		Where("orders.status = ?", models.OrderStatusPicked).
+		Where(condition, args...).
+		Group("orders.store").
+		Scan(&pending).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve QC statistics", err.Error())
+		return
+	}
+	for _, count := range pending {
+		statistic(count.Store).Pending += count.Total
+	}
+
+	sort.Strings(stores)
+	response := QcStatisticsResponse{Stores: make([]QcStoreStatistic, len(stores))}
+	for i, store := range stores {
+		response.Stores[i] = *statistics[store]
+		response.TotalChecked += statistics[store].Checked
+		response.TotalPending += statistics[store].Pending
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "QC statistics retrieved successfully", response)
+}
+
+// Request/Response structs
+type CreateQcRequest struct {
+	Tracking string         `json:"tracking" binding:"required" example:"JNE1234567890"`
+	Details  []QcBoxRequest `json:"details" binding:"required,min=1,dive"`
+}
+
+type UpdateQcRequest struct {
+	Details []QcBoxRequest `json:"details" binding:"required,min=1,dive"`
+}
+
+type QcBoxRequest struct {
+	BoxID    uint `json:"box_id" binding:"required" example:"1"`
+	Quantity int  `json:"quantity" binding:"required,min=1" example:"1"`
+}
+
+type QcStoreStatistic struct {
+	Store   string `json:"store"`
+	Checked int64  `json:"checked"`
+	Pending int64  `json:"pending"`
+}
+
+type QcStatisticsResponse struct {
+	TotalChecked int64              `json:"total_checked"`
+	TotalPending int64              `json:"total_pending"`
+	Stores       []QcStoreStatistic `json:"stores"`
+}
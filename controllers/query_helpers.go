@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// applyDateRange applies start_date and end_date query parameters to the given column. Dates are days of the
+// business timezone, so a day covers the warehouse's day whatever the timezone of the server.
+// It writes the error response and returns false when a date is invalid.
+func applyDateRange(c *gin.Context, query *gorm.DB, column string) (*gorm.DB, bool) {
+	if startDate := c.Query("start_date"); startDate != "" {
+		parsedStartDate, err := utils.ParseBusinessDate(startDate)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid start_date format", "start_date must be in YYYY-MM-DD format")
+			return query, false
+		}
+		query = query.Where(column+" >= ?", parsedStartDate)
+	}
+
+	if endDate := c.Query("end_date"); endDate != "" {
+		parsedEndDate, err := utils.ParseBusinessDate(endDate)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid end_date format", "end_date must be in YYYY-MM-DD format")
+			return query, false
+		}
+		query = query.Where(column+" < ?", parsedEndDate.AddDate(0, 0, 1))
+	}
+
+	return query, true
+}
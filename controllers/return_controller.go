@@ -0,0 +1,704 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnController struct {
+	DB *gorm.DB
+}
+
+// NewReturnController creates a new return controller
+func NewReturnController(db *gorm.DB) *ReturnController {
+	return &ReturnController{DB: db}
+}
+
+// LookupReturnOrder godoc
+// @Summary Look up original order of a return
+// @Description Find the original order of a returned parcel by its old tracking or order ginee ID, with its details and previous returns.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tracking query string false "Old tracking number"
+// @Param order_ginee_id query string false "Order ginee ID"
+// @Success 200 {object} utils.Response{data=ReturnOrderLookupResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/returns/lookup [get]
+func (rc *ReturnController) LookupReturnOrder(c *gin.Context) {
+	tracking := strings.TrimSpace(c.Query("tracking"))
+	orderGineeID := strings.TrimSpace(c.Query("order_ginee_id"))
+	if tracking == "" && orderGineeID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", "either tracking or order_ginee_id is required")
+		return
+	}
+
+	order, err := models.ResolveOrder(rc.DB, tracking, orderGineeID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Order not found", "no order found with the specified tracking or order ginee ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find order", err.Error())
+		return
+	}
+
+	var previousReturns []models.Return
+	rc.DB.Where("old_tracking = ? OR order_ginee_id = ?", order.Tracking, order.OrderGineeID).
+		Preload("ReturnDetails.Product").
+		Order("id DESC").
+		Find(&previousReturns)
+
+	response := ReturnOrderLookupResponse{
+		Order:           order.ToOrderResponse(),
+		PreviousReturns: models.ToReturnResponses(previousReturns),
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Order retrieved successfully", response)
+}
+
+// CreateMobileReturn godoc
+// @Summary Receive returned parcel
+// @Description Register a returned parcel at the warehouse door by its new tracking. The return is completed later with the original order and received products.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateMobileReturnRequest true "Receive returned parcel request"
+// @Success 201 {object} utils.Response{data=models.ReturnMobileResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/returns/mobile [post]
+func (rc *ReturnController) CreateMobileReturn(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateMobileReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.NewTracking = strings.TrimSpace(req.NewTracking)
+	if status, err := rc.validateNewTracking(req.NewTracking, 0); err != nil {
+		utils.ErrorResponse(c, status, "Invalid new tracking", err.Error())
+		return
+	}
+
+	if err := rc.validateReturnReferences(req.ChannelID, req.StoreID, nil); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid return", err.Error())
+		return
+	}
+
+	ret := models.Return{
+		NewTracking: req.NewTracking,
+		ChannelID:   req.ChannelID,
+		StoreID:     req.StoreID,
+		CreatorID:   &creatorID,
+	}
+
+	if err := rc.DB.Create(&ret).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create return", err.Error())
+		return
+	}
+
+	rc.DB.Preload("Channel").Preload("Store").First(&ret, ret.ID)
+
+	utils.SuccessResponse(c, http.StatusCreated, "Return received successfully", ret.ToReturnMobileResponse())
+}
+
+// CreateReturn godoc
+// @Summary Create return
+// @Description Record a return with its original order (by old tracking or order ginee ID) and the received products. The restock or scrap outcome of each line can be decided now or before processing.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ReturnRequest true "Create return request"
+// @Success 201 {object} utils.Response{data=models.ReturnResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/returns [post]
+func (rc *ReturnController) CreateReturn(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	ret := models.Return{CreatorID: &creatorID}
+	if status, err := rc.applyReturnRequest(&ret, &req); err != nil {
+		utils.ErrorResponse(c, status, "Invalid return", err.Error())
+		return
+	}
+
+	if err := rc.DB.Create(&ret).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create return", err.Error())
+		return
+	}
+
+	rc.respondWithReturn(c, http.StatusCreated, "Return created successfully", ret.ID)
+}
+
+// GetReturns godoc
+// @Summary Get all returns
+// @Description Get list of returns with pagination, optional processed and date range filtering, and search by tracking, order ginee ID, return number or scrap number.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param processed query bool false "Filter by processed state"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Param search query string false "Search by new/old tracking, order ginee ID, return number or scrap number"
+// @Success 200 {object} utils.Response{data=ReturnsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/returns [get]
+func (rc *ReturnController) GetReturns(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	search := strings.TrimSpace(c.Query("search"))
+	processed := c.Query("processed")
+
+	var returns []models.Return
+	var total int64
+
+	query := rc.DB.Model(&models.Return{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if processed != "" {
+		isProcessed, err := strconv.ParseBool(processed)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid processed filter", "processed must be true or false")
+			return
+		}
+		if isProcessed {
+			query = query.Where("processed_at IS NOT NULL")
+		} else {
+			query = query.Where("processed_at IS NULL")
+		}
+	}
+
+	if search != "" {
+		query = query.Where("new_tracking ILIKE ? OR old_tracking ILIKE ? OR order_ginee_id ILIKE ? OR return_number ILIKE ? OR scrap_number ILIKE ?",
+			"%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count returns", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("ReturnDetails.Product").
+		Preload("Channel").
+		Preload("Store").
+		Find(&returns).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve returns", err.Error())
+		return
+	}
+
+	response := ReturnsListResponse{
+		Returns: models.ToReturnResponses(returns),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Returns retrieved successfully", response)
+}
+
+// GetReturn godoc
+// @Summary Get return by ID
+// @Description Get a return with its received products and original order.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/returns/{id} [get]
+func (rc *ReturnController) GetReturn(c *gin.Context) {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid return ID", "Return ID must be a valid number")
+		return
+	}
+
+	rc.respondWithReturn(c, http.StatusOK, "Return retrieved successfully", uint(returnID))
+}
+
+// UpdateReturn godoc
+// @Summary Update return
+// @Description Complete or correct an unprocessed return. The received products are replaced by the given list.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Param request body ReturnRequest true "Update return request"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/returns/{id} [put]
+func (rc *ReturnController) UpdateReturn(c *gin.Context) {
+	var req ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	ret, ok := rc.findReturn(c)
+	if !ok {
+		return
+	}
+
+	if ret.ProcessedAt != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Return already processed", "processed returns can no longer be updated")
+		return
+	}
+
+	if status, err := rc.applyReturnRequest(ret, &req); err != nil {
+		utils.ErrorResponse(c, status, "Invalid return", err.Error())
+		return
+	}
+
+	if err := rc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("return_id = ?", ret.ID).Delete(&models.ReturnDetail{}).Error; err != nil {
+			return err
+		}
+		for i := range ret.ReturnDetails {
+			ret.ReturnDetails[i].ReturnID = ret.ID
+		}
+		if len(ret.ReturnDetails) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&ret.ReturnDetails).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(ret).Error
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update return", err.Error())
+		return
+	}
+
+	rc.respondWithReturn(c, http.StatusOK, "Return updated successfully", ret.ID)
+}
+
+// ProcessReturn godoc
+// @Summary Process return
+// @Description Finalize a return once every received product has a restock or scrap outcome. A return number is generated when products are restocked and a scrap number when products are scrapped. A shipped original order moves to "returned".
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/returns/{id}/process [put]
+func (rc *ReturnController) ProcessReturn(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	processorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var ret models.Return
+	statusCode := http.StatusInternalServerError
+	if err := rc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ret, c.Param("id")).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no return found with the specified ID")
+			}
+			return err
+		}
+
+		if ret.ProcessedAt != nil {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("return has already been processed")
+		}
+
+		if err := tx.Where("return_id = ?", ret.ID).Find(&ret.ReturnDetails).Error; err != nil {
+			return err
+		}
+
+		statusCode = http.StatusUnprocessableEntity
+		if ret.OldTracking == "" && ret.OrderGineeID == "" {
+			return fmt.Errorf("the original order (old tracking or order ginee ID) must be recorded before processing")
+		}
+		if len(ret.ReturnDetails) == 0 {
+			return fmt.Errorf("the received products must be recorded before processing")
+		}
+		for _, detail := range ret.ReturnDetails {
+			if detail.Outcome != models.ReturnOutcomeRestock && detail.Outcome != models.ReturnOutcomeScrap {
+				return fmt.Errorf("product %d has no restock or scrap outcome", detail.ProductID)
+			}
+		}
+
+		statusCode = http.StatusInternalServerError
+		return processReturn(tx, &ret, processorID)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to process return", err.Error())
+		return
+	}
+
+	rc.respondWithReturn(c, http.StatusOK, "Return processed successfully", ret.ID)
+}
+
+// DeleteReturn godoc
+// @Summary Delete return
+// @Description Delete an unprocessed return with its received products.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/returns/{id} [delete]
+func (rc *ReturnController) DeleteReturn(c *gin.Context) {
+	ret, ok := rc.findReturn(c)
+	if !ok {
+		return
+	}
+
+	if ret.ProcessedAt != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Return already processed", "processed returns can no longer be deleted")
+		return
+	}
+
+	if err := rc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("return_id = ?", ret.ID).Delete(&models.ReturnDetail{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(ret).Error
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete return", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Return deleted successfully", nil)
+}
+
+// processReturn generates the return and scrap numbers of the decided outcomes, records the stock movements,
+// moves a shipped original order to "returned" and marks the return as processed. Every detail must have an outcome.
+func processReturn(tx *gorm.DB, ret *models.Return, processorID uint) error {
+	hasRestock, hasScrap := false, false
+	for _, detail := range ret.ReturnDetails {
+		switch detail.Outcome {
+		case models.ReturnOutcomeRestock:
+			hasRestock = true
+		case models.ReturnOutcomeScrap:
+			hasScrap = true
+		default:
+			return fmt.Errorf("product %d has no restock or scrap outcome", detail.ProductID)
+		}
+	}
+
+	if hasRestock && ret.ReturnNumber == "" {
+		number, err := utils.GenerateReturnNumber(tx)
+		if err != nil {
+			return err
+		}
+		ret.ReturnNumber = number
+	}
+	if hasScrap && ret.ScrapNumber == "" {
+		number, err := utils.GenerateScrapNumber(tx)
+		if err != nil {
+			return err
+		}
+		ret.ScrapNumber = number
+	}
+
+	// Restocked products go back to their product location, scrapped products to the scrap bin
+	productIDs := make([]uint, len(ret.ReturnDetails))
+	for i, detail := range ret.ReturnDetails {
+		productIDs[i] = detail.ProductID
+	}
+	var products []models.Product
+	if err := tx.Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return err
+	}
+	productsByID := make(map[uint]models.Product)
+	for _, product := range products {
+		productsByID[product.ID] = product
+	}
+
+	for _, detail := range ret.ReturnDetails {
+		product := productsByID[detail.ProductID]
+
+		movement := models.StockMovement{
+			Sku:       product.Sku,
+			Location:  product.Location,
+			Type:      models.StockMovementReturnRestock,
+			Quantity:  detail.Quantity,
+			Reference: ret.ReturnNumber,
+			UserID:    &processorID,
+		}
+		if detail.Outcome == models.ReturnOutcomeScrap {
+			movement.Location = models.ScrapLocation
+			movement.Type = models.StockMovementScrap
+			movement.Reference = ret.ScrapNumber
+		}
+		if err := models.RecordStockMovement(tx, &movement); err != nil {
+			return err
+		}
+	}
+
+	// The order is locked so a cancel or station scan that lands in between is never overwritten
+	order, err := models.ResolveOrder(tx.Clauses(clause.Locking{Strength: "UPDATE"}), ret.OldTracking, ret.OrderGineeID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	ret.Order = order
+	if ret.Order != nil && ret.Order.CanTransitionTo(models.OrderStatusReturned) {
+		previousStatus := ret.Order.Status
+		if err := ret.Order.TransitionTo(models.OrderStatusReturned, &processorID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(ret.Order).Error; err != nil {
+			return err
+		}
+		// Returns that were only scrapped have no return number
+		reference := ret.ReturnNumber
+		if reference == "" {
+			reference = ret.ScrapNumber
+		}
+		if err := models.RecordOrderEvents(tx, models.NewOrderStatusEvent(ret.Order, previousStatus, &processorID, "Return "+reference)); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	ret.ProcessorID = &processorID
+	ret.ProcessedAt = &now
+	return tx.Omit(clause.Associations).Save(ret).Error
+}
+
+// applyReturnRequest validates the request and copies it to the return, completing the original order identifiers.
+// It returns the HTTP status to respond with when the request is invalid.
+func (rc *ReturnController) applyReturnRequest(ret *models.Return, req *ReturnRequest) (int, error) {
+	req.NewTracking = strings.TrimSpace(req.NewTracking)
+	req.OldTracking = strings.TrimSpace(req.OldTracking)
+	req.OrderGineeID = strings.TrimSpace(req.OrderGineeID)
+
+	if status, err := rc.validateNewTracking(req.NewTracking, ret.ID); err != nil {
+		return status, err
+	}
+
+	if err := rc.validateReturnReferences(req.ChannelID, req.StoreID, req.ReturnDetails); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	ret.NewTracking = req.NewTracking
+	ret.OldTracking = req.OldTracking
+	ret.OrderGineeID = req.OrderGineeID
+	ret.ChannelID = req.ChannelID
+	ret.StoreID = req.StoreID
+	ret.ReturnType = strings.TrimSpace(req.ReturnType)
+	ret.ReturnReason = strings.TrimSpace(req.ReturnReason)
+
+	// The original order must exist when it is identified
+	if ret.OldTracking != "" || ret.OrderGineeID != "" {
+		order, err := models.ResolveOrder(rc.DB, ret.OldTracking, ret.OrderGineeID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return http.StatusNotFound, fmt.Errorf("no order found with the specified old tracking or order ginee ID")
+			}
+			return http.StatusInternalServerError, err
+		}
+		ret.OldTracking = order.Tracking
+		ret.OrderGineeID = order.OrderGineeID
+	}
+
+	ret.ReturnDetails = make([]models.ReturnDetail, len(req.ReturnDetails))
+	for i, detail := range req.ReturnDetails {
+		ret.ReturnDetails[i] = models.ReturnDetail{
+			ProductID: detail.ProductID,
+			Quantity:  detail.Quantity,
+			Outcome:   detail.Outcome,
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// validateNewTracking verifies that the new tracking is not used by another return
+func (rc *ReturnController) validateNewTracking(newTracking string, returnID uint) (int, error) {
+	if newTracking == "" {
+		return http.StatusBadRequest, fmt.Errorf("new_tracking is required")
+	}
+
+	var count int64
+	rc.DB.Model(&models.Return{}).Where("new_tracking = ? AND id <> ?", newTracking, returnID).Count(&count)
+	if count > 0 {
+		return http.StatusConflict, fmt.Errorf("a return with new tracking '%s' already exists", newTracking)
+	}
+
+	return http.StatusOK, nil
+}
+
+// validateReturnReferences verifies that the channel, store and received products exist
+func (rc *ReturnController) validateReturnReferences(channelID, storeID uint, details []ReturnDetailRequest) error {
+	var count int64
+	if rc.DB.Model(&models.Channel{}).Where("id = ?", channelID).Count(&count); count == 0 {
+		return fmt.Errorf("channel %d not found", channelID)
+	}
+	if rc.DB.Model(&models.Store{}).Where("id = ?", storeID).Count(&count); count == 0 {
+		return fmt.Errorf("store %d not found", storeID)
+	}
+
+	for _, detail := range details {
+		if rc.DB.Model(&models.Product{}).Where("id = ?", detail.ProductID).Count(&count); count == 0 {
+			return fmt.Errorf("product %d not found", detail.ProductID)
+		}
+	}
+
+	return nil
+}
+
+// findReturn loads the return of the id path parameter, responding with an error when not found
+func (rc *ReturnController) findReturn(c *gin.Context) (*models.Return, bool) {
+	var ret models.Return
+	if err := rc.DB.First(&ret, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Return not found", "no return found with the specified ID")
+			return nil, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find return", err.Error())
+		return nil, false
+	}
+
+	return &ret, true
+}
+
+// respondWithReturn loads the return with its received products and original order
+func (rc *ReturnController) respondWithReturn(c *gin.Context, statusCode int, message string, returnID uint) {
+	var ret models.Return
+	if err := rc.DB.
+		Preload("ReturnDetails.Product").
+		Preload("Channel").
+		Preload("Store").
+		First(&ret, returnID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Return not found", "no return found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve return", err.Error())
+		return
+	}
+
+	ret.Order, _ = models.ResolveOrder(rc.DB, ret.OldTracking, ret.OrderGineeID)
+
+	utils.SuccessResponse(c, statusCode, message, ret.ToReturnResponse())
+}
+
+// Request/Response structs
+type CreateMobileReturnRequest struct {
+	NewTracking string `json:"new_tracking" binding:"required" example:"JP0987654321"`
+	ChannelID   uint   `json:"channel_id" binding:"required" example:"1"`
+	StoreID     uint   `json:"store_id" binding:"required" example:"1"`
+}
+
+type ReturnRequest struct {
+	NewTracking   string                `json:"new_tracking" binding:"required" example:"JP0987654321"`
+	OldTracking   string                `json:"old_tracking" example:"JP1234567890"`
+	OrderGineeID  string                `json:"order_ginee_id" example:"2510080001"`
+	ChannelID     uint                  `json:"channel_id" binding:"required" example:"1"`
+	StoreID       uint                  `json:"store_id" binding:"required" example:"1"`
+	ReturnType    string                `json:"return_type" example:"buyer return"`
+	ReturnReason  string                `json:"return_reason" example:"Wrong size"`
+	ReturnDetails []ReturnDetailRequest `json:"return_details" binding:"dive"`
+}
+
+type ReturnDetailRequest struct {
+	ProductID uint   `json:"product_id" binding:"required" example:"1"`
+	Quantity  int    `json:"quantity" binding:"required,min=1" example:"1"`
+	Outcome   string `json:"outcome" binding:"omitempty,oneof=restock scrap" example:"restock"`
+}
+
+type ReturnOrderLookupResponse struct {
+	Order           models.OrderResponse    `json:"order"`
+	PreviousReturns []models.ReturnResponse `json:"previous_returns"`
+}
+
+type ReturnsListResponse struct {
+	Returns    []models.ReturnResponse  `json:"returns"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StockController struct {
+	DB *gorm.DB
+}
+
+// NewStockController creates a new stock controller
+func NewStockController(db *gorm.DB) *StockController {
+	return &StockController{DB: db}
+}
+
+// GetStocks godoc
+// @Summary Get stock on hand
+// @Description Get the stock on hand per SKU and bin with pagination, optional location filter and search by SKU.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param location query string false "Filter by bin location"
+// @Param search query string false "Search by SKU (partial match)"
+// @Success 200 {object} utils.Response{data=StocksListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/stocks [get]
+func (sc *StockController) GetStocks(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	search := strings.TrimSpace(c.Query("search"))
+	location := strings.TrimSpace(c.Query("location"))
+
+	var stocks []models.Stock
+	var total int64
+
+	query := sc.DB.Model(&models.Stock{})
+
+	if location != "" {
+		query = query.Where("location = ?", location)
+	}
+
+	if search != "" {
+		query = query.Where("sku ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count stocks", err.Error())
+		return
+	}
+
+	if err := query.Order("sku ASC, location ASC").Limit(limit).Offset(offset).Find(&stocks).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stocks", err.Error())
+		return
+	}
+
+	response := StocksListResponse{
+		Stocks: sc.toStockResponses(stocks),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Stocks retrieved successfully", response)
+}
+
+// GetSkuStock godoc
+// @Summary Get stock of a SKU
+// @Description Get the stock on hand of a SKU in every bin with its total. The scrap bin is not counted in the sellable total.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sku path string true "Product SKU"
+// @Success 200 {object} utils.Response{data=SkuStockResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/stocks/{sku} [get]
+func (sc *StockController) GetSkuStock(c *gin.Context) {
+	sku := strings.TrimSpace(c.Param("sku"))
+
+	var stocks []models.Stock
+	if err := sc.DB.Where("sku = ?", sku).Order("location ASC").Find(&stocks).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stock", err.Error())
+		return
+	}
+
+	response := SkuStockResponse{
+		Sku:       sku,
+		Locations: sc.toStockResponses(stocks),
+	}
+	for _, stock := range stocks {
+		if stock.Location == models.ScrapLocation {
+			response.Scrapped += stock.Quantity
+			continue
+		}
+		response.OnHand += stock.Quantity
+	}
+
+	var product models.Product
+	if err := sc.DB.Where("sku = ?", sku).First(&product).Error; err == nil {
+		response.ProductName = product.Name
+		response.Variant = product.Variant
+		response.DefaultLocation = product.Location
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Stock retrieved successfully", response)
+}
+
+// GetStockMovements godoc
+// @Summary Get stock movements of a SKU
+// @Description Get the movement history of a SKU, newest first, with pagination and optional type, location and date range filtering.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sku path string true "Product SKU"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param type query string false "Filter by movement type (receipt, pick, return_restock, cancel_restock, scrap, adjustment)"
+// @Param location query string false "Filter by bin location"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=StockMovementsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/stocks/{sku}/movements [get]
+func (sc *StockController) GetStockMovements(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var movements []models.StockMovement
+	var total int64
+
+	query := sc.DB.Model(&models.StockMovement{}).Where("sku = ?", strings.TrimSpace(c.Param("sku")))
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if movementType := c.Query("type"); movementType != "" {
+		query = query.Where("type = ?", movementType)
+	}
+
+	if location := c.Query("location"); location != "" {
+		query = query.Where("location = ?", location)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count stock movements", err.Error())
+		return
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Preload("User").Find(&movements).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stock movements", err.Error())
+		return
+	}
+
+	movementResponses := make([]models.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		movementResponses[i] = movement.ToStockMovementResponse()
+	}
+
+	response := StockMovementsListResponse{
+		Movements: movementResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Stock movements retrieved successfully", response)
+}
+
+// CreateStockMovement godoc
+// @Summary Record stock movement
+// @Description Record a manual stock movement: a receipt of goods (positive quantity), a scrap of damaged stock (positive quantity, taken out of the bin) or an adjustment (signed quantity). The bin defaults to the product location.
+// @Tags stocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateStockMovementRequest true "Create stock movement request"
+// @Success 201 {object} utils.Response{data=models.StockMovementResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/stocks/movements [post]
+func (sc *StockController) CreateStockMovement(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	operatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateStockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	req.Sku = strings.TrimSpace(req.Sku)
+	req.Location = strings.TrimSpace(req.Location)
+
+	var product models.Product
+	if err := sc.DB.Where("sku = ?", req.Sku).First(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Product not found", fmt.Sprintf("no product found with SKU '%s'", req.Sku))
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find product", err.Error())
+		return
+	}
+
+	quantity := req.Quantity
+	switch req.Type {
+	case models.StockMovementReceipt:
+		if quantity <= 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid quantity", "receipt quantity must be positive")
+			return
+		}
+	case models.StockMovementScrap:
+		if quantity <= 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid quantity", "scrap quantity must be positive")
+			return
+		}
+		quantity = -quantity
+	case models.StockMovementAdjustment:
+		if quantity == 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid quantity", "adjustment quantity must not be zero")
+			return
+		}
+	}
+
+	location := req.Location
+	if location == "" {
+		location = product.Location
+	}
+
+	movement := models.StockMovement{
+		Sku:       product.Sku,
+		Location:  location,
+		Type:      req.Type,
+		Quantity:  quantity,
+		Reference: strings.TrimSpace(req.Reference),
+		Note:      strings.TrimSpace(req.Note),
+		UserID:    &operatorID,
+	}
+
+	if err := sc.DB.Transaction(func(tx *gorm.DB) error {
+		return models.RecordStockMovement(tx, &movement)
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record stock movement", err.Error())
+		return
+	}
+
+	sc.DB.Preload("User").First(&movement, movement.ID)
+
+	utils.SuccessResponse(c, http.StatusCreated, "Stock movement recorded successfully", movement.ToStockMovementResponse())
+}
+
+// toStockResponses converts stocks to responses with their product names loaded in one query
+func (sc *StockController) toStockResponses(stocks []models.Stock) []models.StockResponse {
+	skus := make([]string, len(stocks))
+	for i, stock := range stocks {
+		skus[i] = stock.Sku
+	}
+
+	products := make(map[string]models.Product)
+	if len(skus) > 0 {
+		var found []models.Product
+		sc.DB.Where("sku IN ?", skus).Find(&found)
+		for _, product := range found {
+			products[product.Sku] = product
+		}
+	}
+
+	responses := make([]models.StockResponse, len(stocks))
+	for i, stock := range stocks {
+		if product, exists := products[stock.Sku]; exists {
+			responses[i] = stock.ToStockResponse(&product)
+			continue
+		}
+		responses[i] = stock.ToStockResponse(nil)
+	}
+
+	return responses
+}
+
+// Request/Response structs
+type CreateStockMovementRequest struct {
+	Sku       string `json:"sku" binding:"required" example:"SKU-001"`
+	Location  string `json:"location" example:"A-01-02"`
+	Type      string `json:"type" binding:"required,oneof=receipt scrap adjustment" example:"receipt"`
+	Quantity  int    `json:"quantity" binding:"required" example:"10"`
+	Reference string `json:"reference" example:"PO-2025-001"`
+	Note      string `json:"note" example:"Supplier delivery"`
+}
+
+type StocksListResponse struct {
+	Stocks     []models.StockResponse   `json:"stocks"`
+	Pagination utils.PaginationResponse `json:"pagination"`
+}
+
+type SkuStockResponse struct {
+	Sku             string                 `json:"sku"`
+	ProductName     string                 `json:"product_name"`
+	Variant         string                 `json:"variant"`
+	DefaultLocation string                 `json:"default_location"`
+	OnHand          int                    `json:"on_hand"`
+	Scrapped        int                    `json:"scrapped"`
+	Locations       []models.StockResponse `json:"locations"`
+}
+
+type StockMovementsListResponse struct {
+	Movements  []models.StockMovementResponse `json:"movements"`
+	Pagination utils.PaginationResponse       `json:"pagination"`
+}
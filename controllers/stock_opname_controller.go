@@ -0,0 +1,968 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/jobs"
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockOpnameController struct {
+	DB *gorm.DB
+}
+
+// NewStockOpnameController creates a new stock opname controller
+func NewStockOpnameController(db *gorm.DB) *StockOpnameController {
+	return &StockOpnameController{DB: db}
+}
+
+// CreateStockOpname godoc
+// @Summary Create stock opname
+// @Description Start a physical count session for a set of bin locations and/or SKUs. A line is created for every product located in the bins or with the SKUs, and for every bin already holding stock of them. The scrap bin is only counted when listed explicitly.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateStockOpnameRequest true "Create stock opname request"
+// @Success 201 {object} utils.Response{data=models.StockOpnameResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/stock-opnames [post]
+func (soc *StockOpnameController) CreateStockOpname(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateStockOpnameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	opname := models.StockOpname{
+		Status:    models.StockOpnameStatusCounting,
+		Locations: models.JoinStockOpnameScope(req.Locations),
+		Skus:      models.JoinStockOpnameScope(req.Skus),
+		Notes:     strings.TrimSpace(req.Notes),
+		CreatorID: creatorID,
+	}
+
+	if opname.Locations == "" && opname.Skus == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid scope", "at least one location or SKU is required")
+		return
+	}
+
+	lines, err := soc.buildStockOpnameLines(&opname)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to build stock opname lines", err.Error())
+		return
+	}
+	if len(lines) == 0 {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Nothing to count", "no products or stock found in the given locations and SKUs")
+		return
+	}
+
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		code, err := utils.GenerateOpnameCode(tx)
+		if err != nil {
+			return err
+		}
+		opname.Code = code
+
+		if err := tx.Omit(clause.Associations).Create(&opname).Error; err != nil {
+			return err
+		}
+
+		for i := range lines {
+			lines[i].StockOpnameID = opname.ID
+		}
+		return tx.Create(&lines).Error
+	}); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create stock opname", err.Error())
+		return
+	}
+
+	soc.respondWithStockOpname(c, http.StatusCreated, "Stock opname created successfully", opname.ID)
+}
+
+// GetStockOpnames godoc
+// @Summary Get all stock opnames
+// @Description Get list of stock opname sessions with their progress, pagination, optional status and date range filtering. Lines are not included; use the stock opname detail endpoint.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (counting, completed, cancelled)"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=StockOpnamesListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/stock-opnames [get]
+func (soc *StockOpnameController) GetStockOpnames(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var opnames []models.StockOpname
+	var total int64
+
+	query := soc.DB.Model(&models.StockOpname{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count stock opnames", err.Error())
+		return
+	}
+
+	// Lines are only loaded for the progress counts
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).
+		Preload("Creator").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "stock_opname_id", "status", "expected_quantity", "counted_quantity")
+		}).
+		Find(&opnames).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stock opnames", err.Error())
+		return
+	}
+
+	opnameResponses := make([]models.StockOpnameResponse, len(opnames))
+	for i, opname := range opnames {
+		opnameResponses[i] = opname.ToStockOpnameResponse()
+		opnameResponses[i].Lines = nil
+	}
+
+	response := StockOpnamesListResponse{
+		StockOpnames: opnameResponses,
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Stock opnames retrieved successfully", response)
+}
+
+// GetStockOpname godoc
+// @Summary Get stock opname by ID
+// @Description Get a stock opname session with its lines ordered by location and SKU
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Success 200 {object} utils.Response{data=models.StockOpnameResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/stock-opnames/{id} [get]
+func (soc *StockOpnameController) GetStockOpname(c *gin.Context) {
+	opnameID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid stock opname ID", "Stock opname ID must be a valid number")
+		return
+	}
+
+	soc.respondWithStockOpname(c, http.StatusOK, "Stock opname retrieved successfully", uint(opnameID))
+}
+
+// ScanStockOpname godoc
+// @Summary Scan stock opname item
+// @Description Add a scanned item to the count of its line. The product is resolved by barcode (or SKU). The location is required when the product is counted in several bins; scanning a product found in a counted bin without a line adds the line. Quantity defaults to 1.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Param request body ScanStockOpnameRequest true "Scan stock opname request"
+// @Success 200 {object} utils.Response{data=models.StockOpnameLineResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/stock-opnames/{id}/scans [post]
+func (soc *StockOpnameController) ScanStockOpname(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	counterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req ScanStockOpnameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	barcode := strings.TrimSpace(req.Barcode)
+	if barcode == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid barcode", "barcode must not be empty")
+		return
+	}
+
+	location := strings.TrimSpace(req.Location)
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	var line models.StockOpnameLine
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		opname, err := lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		product, err := models.FindProductByBarcode(tx, barcode)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				statusCode = http.StatusNotFound
+				return fmt.Errorf("no product found with barcode '%s'", barcode)
+			}
+			return err
+		}
+
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("stock_opname_id = ? AND sku = ?", opname.ID, product.Sku)
+		if location != "" {
+			query = query.Where("location = ?", location)
+		}
+
+		var lines []models.StockOpnameLine
+		if err := query.Find(&lines).Error; err != nil {
+			return err
+		}
+
+		switch len(lines) {
+		case 0:
+			if location == "" || !opname.InLocationScope(location) {
+				statusCode = http.StatusUnprocessableEntity
+				return fmt.Errorf("product '%s' is not counted in this stock opname", product.Sku)
+			}
+
+			// Products found in a counted bin are counted even when they are not expected there
+			line = models.StockOpnameLine{
+				StockOpnameID: opname.ID,
+				Sku:           product.Sku,
+				Location:      location,
+				ProductName:   product.Name,
+				Variant:       product.Variant,
+				Barcode:       product.Barcode,
+				Status:        models.StockOpnameLinePending,
+			}
+			if err := tx.Create(&line).Error; err != nil {
+				return err
+			}
+		case 1:
+			line = lines[0]
+		default:
+			statusCode = http.StatusUnprocessableEntity
+			locations := make([]string, len(lines))
+			for i, l := range lines {
+				locations[i] = l.Location
+			}
+			return fmt.Errorf("product '%s' is counted in several locations (%s), specify the location", product.Sku, strings.Join(locations, ", "))
+		}
+
+		if line.Status == models.StockOpnameLineApproved {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("count of '%s' at '%s' is already approved", line.Sku, line.Location)
+		}
+
+		counted := quantity
+		if line.CountedQuantity != nil {
+			counted += *line.CountedQuantity
+		}
+		return countStockOpnameLine(tx, &line, counted, counterID)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to scan item", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Item scanned successfully", line.ToStockOpnameLineResponse())
+}
+
+// CountStockOpnameLine godoc
+// @Summary Set stock opname line count
+// @Description Set the counted quantity of a line directly, replacing the scanned count. Use quantity 0 for an empty bin.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Param lineId path int true "Stock opname line ID"
+// @Param request body CountStockOpnameLineRequest true "Count stock opname line request"
+// @Success 200 {object} utils.Response{data=models.StockOpnameLineResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/stock-opnames/{id}/lines/{lineId}/count [put]
+func (soc *StockOpnameController) CountStockOpnameLine(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	counterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CountStockOpnameLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var line *models.StockOpnameLine
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		opname, err := lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		line, err = lockStockOpnameLine(tx, opname.ID, c.Param("lineId"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		if line.Status == models.StockOpnameLineApproved {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("count of '%s' at '%s' is already approved", line.Sku, line.Location)
+		}
+
+		return countStockOpnameLine(tx, line, *req.Quantity, counterID)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to count line", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Line counted successfully", line.ToStockOpnameLineResponse())
+}
+
+// ApproveStockOpnameLine godoc
+// @Summary Approve stock opname line
+// @Description Approve the count of a line. A variance is booked as a stock adjustment of the SKU in the line's bin. The session is completed when its last line is approved.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Param lineId path int true "Stock opname line ID"
+// @Success 200 {object} utils.Response{data=models.StockOpnameResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/stock-opnames/{id}/lines/{lineId}/approve [put]
+func (soc *StockOpnameController) ApproveStockOpnameLine(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	approverID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var opname *models.StockOpname
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		opname, err = lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		line, err := lockStockOpnameLine(tx, opname.ID, c.Param("lineId"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		if line.Status != models.StockOpnameLineCounted {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("line status is '%s'. Only counted lines can be approved", line.Status)
+		}
+
+		if err := approveStockOpnameLine(tx, opname, line, approverID); err != nil {
+			return err
+		}
+
+		return completeStockOpname(tx, opname)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to approve line", err.Error())
+		return
+	}
+
+	soc.respondWithStockOpname(c, http.StatusOK, "Line approved successfully", opname.ID)
+}
+
+// ApproveStockOpname godoc
+// @Summary Approve all counted stock opname lines
+// @Description Approve every counted line of a session, booking their variances as stock adjustments. Lines still to be counted or recounted are left as they are. The session is completed when no line is left.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Success 200 {object} utils.Response{data=models.StockOpnameResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/stock-opnames/{id}/approve [put]
+func (soc *StockOpnameController) ApproveStockOpname(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	approverID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var opname *models.StockOpname
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		opname, err = lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		var lines []models.StockOpnameLine
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("stock_opname_id = ? AND status = ?", opname.ID, models.StockOpnameLineCounted).
+			Order("id ASC").
+			Find(&lines).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			statusCode = http.StatusUnprocessableEntity
+			return fmt.Errorf("stock opname has no counted lines to approve")
+		}
+
+		for i := range lines {
+			if err := approveStockOpnameLine(tx, opname, &lines[i], approverID); err != nil {
+				return err
+			}
+		}
+
+		return completeStockOpname(tx, opname)
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to approve stock opname", err.Error())
+		return
+	}
+
+	soc.respondWithStockOpname(c, http.StatusOK, "Stock opname approved successfully", opname.ID)
+}
+
+// RecountStockOpnameLine godoc
+// @Summary Request stock opname line recount
+// @Description Reject the count of a line and clear it so the bin is counted again. The expected quantity is taken again at the recount.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Param lineId path int true "Stock opname line ID"
+// @Success 200 {object} utils.Response{data=models.StockOpnameLineResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/stock-opnames/{id}/lines/{lineId}/recount [put]
+func (soc *StockOpnameController) RecountStockOpnameLine(c *gin.Context) {
+	var line *models.StockOpnameLine
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		opname, err := lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		line, err = lockStockOpnameLine(tx, opname.ID, c.Param("lineId"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		if line.Status != models.StockOpnameLineCounted {
+			statusCode = http.StatusConflict
+			return fmt.Errorf("line status is '%s'. Only counted lines can be recounted", line.Status)
+		}
+
+		line.Status = models.StockOpnameLineRecount
+		line.ExpectedQuantity = nil
+		line.CountedQuantity = nil
+		line.CounterID = nil
+		line.CountedAt = nil
+		return tx.Omit(clause.Associations).Save(line).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to request recount", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Recount requested successfully", line.ToStockOpnameLineResponse())
+}
+
+// CancelStockOpname godoc
+// @Summary Cancel stock opname
+// @Description Cancel a counting session. Adjustments of lines approved before the cancellation are kept.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Success 200 {object} utils.Response{data=models.StockOpnameResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/stock-opnames/{id}/cancel [put]
+func (soc *StockOpnameController) CancelStockOpname(c *gin.Context) {
+	var opname *models.StockOpname
+	statusCode := http.StatusInternalServerError
+	if err := soc.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		opname, err = lockCountingStockOpname(tx, c.Param("id"), &statusCode)
+		if err != nil {
+			return err
+		}
+
+		opname.Status = models.StockOpnameStatusCancelled
+		return tx.Omit(clause.Associations).Save(opname).Error
+	}); err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to cancel stock opname", err.Error())
+		return
+	}
+
+	soc.respondWithStockOpname(c, http.StatusOK, "Stock opname cancelled successfully", opname.ID)
+}
+
+// ExportStockOpname godoc
+// @Summary Export stock opname variance report
+// @Description Queue a background job that exports the expected and counted quantities and variances of a session as CSV. Use variance_only=true to only include counted lines with a variance. Poll GET /api/jobs/{id} and download the file from GET /api/jobs/{id}/download.
+// @Tags stock-opnames
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Stock opname ID"
+// @Param variance_only query bool false "Only include lines with a variance"
+// @Success 202 {object} utils.Response{data=models.JobResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/stock-opnames/{id}/export [post]
+func (soc *StockOpnameController) ExportStockOpname(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	requesterID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	opnameID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid stock opname ID", "Stock opname ID must be a valid number")
+		return
+	}
+
+	var opname models.StockOpname
+	if err := soc.DB.First(&opname, opnameID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Stock opname not found", "no stock opname found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stock opname", err.Error())
+		return
+	}
+
+	params := StockOpnameExportJobParams{
+		StockOpnameID: opname.ID,
+		VarianceOnly:  c.Query("variance_only") == "true",
+	}
+
+	queueJob(c, soc.DB, models.JobTypeExportStockOpname, params, requesterID, "Stock opname export queued")
+}
+
+// RunExportStockOpnameJob writes the variance report of a queued export as the CSV file of the job
+func (soc *StockOpnameController) RunExportStockOpnameJob(ctx context.Context, task *jobs.Task) error {
+	var params StockOpnameExportJobParams
+	if err := task.DecodeParams(&params); err != nil {
+		return err
+	}
+
+	opname, err := soc.loadStockOpname(params.StockOpnameID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return jobs.Permanent(fmt.Errorf("stock opname %d not found", params.StockOpnameID))
+		}
+		return err
+	}
+
+	quantity := func(value *int) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.Itoa(*value)
+	}
+	userName := func(user *models.User) string {
+		if user == nil {
+			return ""
+		}
+		return user.Name
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"Location", "SKU", "Product", "Variant", "Barcode", "Expected", "Counted", "Variance", "Status", "Counted By", "Counted At", "Approved By", "Approved At"})
+	task.SetTotal(len(opname.Lines))
+	for _, line := range opname.Lines {
+		variance := line.Variance()
+		if params.VarianceOnly && (variance == nil || *variance == 0) {
+			continue
+		}
+
+		countedAt, approvedAt := "", ""
+		if line.CountedAt != nil {
+			countedAt = line.CountedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
+		}
+		if line.ApprovedAt != nil {
+			approvedAt = line.ApprovedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
+		}
+
+		writer.Write([]string{
+			line.Location,
+			line.Sku,
+			line.ProductName,
+			line.Variant,
+			line.Barcode,
+			quantity(line.ExpectedQuantity),
+			quantity(line.CountedQuantity),
+			quantity(variance),
+			line.Status,
+			userName(line.Counter),
+			countedAt,
+			userName(line.Approver),
+			approvedAt,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	task.SetProgress(len(opname.Lines))
+	task.SetFile(fmt.Sprintf("stock-opname-%s.csv", opname.Code), "text/csv", buffer.Bytes())
+	return nil
+}
+
+// buildStockOpnameLines creates the lines of a new session from the products and stock in its scope
+func (soc *StockOpnameController) buildStockOpnameLines(opname *models.StockOpname) ([]models.StockOpnameLine, error) {
+	locations := models.SplitStockOpnameScope(opname.Locations)
+	skus := models.SplitStockOpnameScope(opname.Skus)
+
+	productQuery := soc.DB.Model(&models.Product{})
+	stockQuery := soc.DB.Model(&models.Stock{}).Where("quantity <> 0")
+	switch {
+	case len(locations) > 0 && len(skus) > 0:
+		productQuery = productQuery.Where("location IN ? OR sku IN ?", locations, skus)
+		stockQuery = stockQuery.Where("location IN ? OR (sku IN ? AND location <> ?)", locations, skus, models.ScrapLocation)
+	case len(locations) > 0:
+		productQuery = productQuery.Where("location IN ?", locations)
+		stockQuery = stockQuery.Where("location IN ?", locations)
+	default:
+		productQuery = productQuery.Where("sku IN ?", skus)
+		stockQuery = stockQuery.Where("sku IN ? AND location <> ?", skus, models.ScrapLocation)
+	}
+
+	var products []models.Product
+	if err := productQuery.Order("location ASC, sku ASC").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	var stocks []models.Stock
+	if err := stockQuery.Order("location ASC, sku ASC").Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+
+	// Stock may be held in bins other than the product location, so its products are looked up separately
+	productsBySku := make(map[string]models.Product)
+	for _, product := range products {
+		productsBySku[product.Sku] = product
+	}
+	var missingSkus []string
+	for _, stock := range stocks {
+		if _, exists := productsBySku[stock.Sku]; !exists {
+			missingSkus = append(missingSkus, stock.Sku)
+		}
+	}
+	if len(missingSkus) > 0 {
+		var stockProducts []models.Product
+		if err := soc.DB.Where("sku IN ?", missingSkus).Find(&stockProducts).Error; err != nil {
+			return nil, err
+		}
+		for _, product := range stockProducts {
+			productsBySku[product.Sku] = product
+		}
+	}
+
+	var lines []models.StockOpnameLine
+	added := make(map[string]bool)
+	addLine := func(sku, location string) {
+		key := sku + "|" + location
+		if added[key] {
+			return
+		}
+		added[key] = true
+
+		product := productsBySku[sku]
+		lines = append(lines, models.StockOpnameLine{
+			Sku:         sku,
+			Location:    location,
+			ProductName: product.Name,
+			Variant:     product.Variant,
+			Barcode:     product.Barcode,
+			Status:      models.StockOpnameLinePending,
+		})
+	}
+
+	for _, product := range products {
+		addLine(product.Sku, product.Location)
+	}
+	for _, stock := range stocks {
+		addLine(stock.Sku, stock.Location)
+	}
+
+	return lines, nil
+}
+
+// loadStockOpname loads a stock opname with its lines ordered by location and SKU
+func (soc *StockOpnameController) loadStockOpname(opnameID uint) (*models.StockOpname, error) {
+	var opname models.StockOpname
+	if err := soc.DB.
+		Preload("Creator").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("location ASC, sku ASC")
+		}).
+		Preload("Lines.Counter").
+		Preload("Lines.Approver").
+		First(&opname, opnameID).Error; err != nil {
+		return nil, err
+	}
+
+	return &opname, nil
+}
+
+// respondWithStockOpname loads the stock opname and responds with its lines
+func (soc *StockOpnameController) respondWithStockOpname(c *gin.Context, statusCode int, message string, opnameID uint) {
+	opname, err := soc.loadStockOpname(opnameID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Stock opname not found", "no stock opname found with the specified ID")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve stock opname", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, statusCode, message, opname.ToStockOpnameResponse())
+}
+
+// lockCountingStockOpname locks the stock opname until the transaction ends and verifies it is still counting.
+// On failure the response status is set in statusCode.
+func lockCountingStockOpname(tx *gorm.DB, opnameID string, statusCode *int) (*models.StockOpname, error) {
+	var opname models.StockOpname
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&opname, opnameID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			*statusCode = http.StatusNotFound
+			return nil, fmt.Errorf("no stock opname found with the specified ID")
+		}
+		return nil, err
+	}
+
+	if opname.Status != models.StockOpnameStatusCounting {
+		*statusCode = http.StatusConflict
+		return nil, fmt.Errorf("stock opname status is '%s'. Only counting sessions can be changed", opname.Status)
+	}
+
+	return &opname, nil
+}
+
+// lockStockOpnameLine locks a line of the stock opname until the transaction ends.
+// On failure the response status is set in statusCode.
+func lockStockOpnameLine(tx *gorm.DB, opnameID uint, lineID string, statusCode *int) (*models.StockOpnameLine, error) {
+	var line models.StockOpnameLine
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stock_opname_id = ?", opnameID).
+		First(&line, lineID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			*statusCode = http.StatusNotFound
+			return nil, fmt.Errorf("no line found with the specified ID in this stock opname")
+		}
+		return nil, err
+	}
+
+	return &line, nil
+}
+
+// countStockOpnameLine sets the counted quantity of a line. The expected quantity is taken from the stock ledger
+// on the first count, so later picks and returns move the books and the bin alike.
+func countStockOpnameLine(tx *gorm.DB, line *models.StockOpnameLine, counted int, counterID uint) error {
+	if line.ExpectedQuantity == nil {
+		var stock models.Stock
+		expected := 0
+		if err := tx.Where("sku = ? AND location = ?", line.Sku, line.Location).First(&stock).Error; err == nil {
+			expected = stock.Quantity
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		line.ExpectedQuantity = &expected
+	}
+
+	now := time.Now()
+	line.CountedQuantity = &counted
+	line.CounterID = &counterID
+	line.CountedAt = &now
+	line.Status = models.StockOpnameLineCounted
+	return tx.Omit(clause.Associations).Save(line).Error
+}
+
+// approveStockOpnameLine approves a counted line and books its variance as a stock adjustment
+func approveStockOpnameLine(tx *gorm.DB, opname *models.StockOpname, line *models.StockOpnameLine, approverID uint) error {
+	if variance := line.Variance(); variance != nil && *variance != 0 {
+		movement := models.StockMovement{
+			Sku:       line.Sku,
+			Location:  line.Location,
+			Type:      models.StockMovementAdjustment,
+			Quantity:  *variance,
+			Reference: opname.Code,
+			Note:      fmt.Sprintf("Stock opname: expected %d, counted %d", *line.ExpectedQuantity, *line.CountedQuantity),
+			UserID:    &approverID,
+		}
+		if err := models.RecordStockMovement(tx, &movement); err != nil {
+			return err
+		}
+		line.MovementID = &movement.ID
+	}
+
+	now := time.Now()
+	line.Status = models.StockOpnameLineApproved
+	line.ApproverID = &approverID
+	line.ApprovedAt = &now
+	return tx.Omit(clause.Associations).Save(line).Error
+}
+
+// completeStockOpname completes the session when every line is approved
+func completeStockOpname(tx *gorm.DB, opname *models.StockOpname) error {
+	var remaining int64
+	if err := tx.Model(&models.StockOpnameLine{}).
+		Where("stock_opname_id = ? AND status <> ?", opname.ID, models.StockOpnameLineApproved).
+		Count(&remaining).Error; err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	opname.Status = models.StockOpnameStatusCompleted
+	opname.CompletedAt = &now
+	return tx.Omit(clause.Associations).Save(opname).Error
+}
+
+// Request/Response structs
+type CreateStockOpnameRequest struct {
+	Locations []string `json:"locations" example:"A-01-01,A-01-02"`
+	Skus      []string `json:"skus" example:"SKU-001"`
+	Notes     string   `json:"notes" example:"Monthly count of rack A"`
+}
+
+type ScanStockOpnameRequest struct {
+	Barcode  string `json:"barcode" binding:"required" example:"8991234567890"`
+	Location string `json:"location" example:"A-01-02"`
+	Quantity *int   `json:"quantity" binding:"omitempty,min=1" example:"1"`
+}
+
+type CountStockOpnameLineRequest struct {
+	Quantity *int `json:"quantity" binding:"required,min=0" example:"12"`
+}
+
+type StockOpnamesListResponse struct {
+	StockOpnames []models.StockOpnameResponse `json:"stock_opnames"`
+	Pagination   utils.PaginationResponse     `json:"pagination"`
+}
+
+// StockOpnameExportJobParams is the session of a queued variance report export
+type StockOpnameExportJobParams struct {
+	StockOpnameID uint `json:"stock_opname_id"`
+	VarianceOnly  bool `json:"variance_only"`
+}
@@ -153,11 +153,21 @@ func (sc *StoreController) UpdateStore(c *gin.Context) {
 		return
 	}
 
+	before := store
+
 	// Update store fields
 	store.Code = req.Code
 	store.Name = req.Name
+	if req.IsRibbon != nil {
+		store.IsRibbon = *req.IsRibbon
+	}
 
-	if err := sc.DB.Save(&store).Error; err != nil {
+	if err := sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&store).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdate, models.AuditEntityStore, store.ID, before, store)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update store", err.Error())
 		return
 	}
@@ -187,7 +197,12 @@ func (sc *StoreController) RemoveStore(c *gin.Context) {
 		return
 	}
 
-	if err := sc.DB.Delete(&store).Error; err != nil {
+	if err := sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&store).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionDelete, models.AuditEntityStore, store.ID, store, nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete store", err.Error())
 		return
 	}
@@ -219,8 +234,9 @@ func (sc *StoreController) CreateStore(c *gin.Context) {
 	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
 
 	store := models.Store{
-		Code: req.Code,
-		Name: req.Name,
+		Code:     req.Code,
+		Name:     req.Name,
+		IsRibbon: req.IsRibbon,
 	}
 	// Check for duplicate store code
 	var existingStore models.Store
@@ -230,7 +246,12 @@ func (sc *StoreController) CreateStore(c *gin.Context) {
 	}
 
 	// Create new store and return response
-	if err := sc.DB.Create(&store).Error; err != nil {
+	if err := sc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&store).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionCreate, models.AuditEntityStore, store.ID, nil, store)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create store", err.Error())
 		return
 	}
@@ -245,11 +266,13 @@ type StoresListResponse struct {
 }
 
 type UpdateStoreRequest struct {
-	Code string `json:"code" binding:"required"`
-	Name string `json:"name" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	IsRibbon *bool  `json:"is_ribbon" example:"false"`
 }
 
 type CreateStoreRequest struct {
-	Code string `json:"code" binding:"required"`
-	Name string `json:"name" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	IsRibbon bool   `json:"is_ribbon" example:"false"`
 }
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TrackingController struct {
+	DB *gorm.DB
+}
+
+// NewTrackingController creates a new tracking controller
+func NewTrackingController(db *gorm.DB) *TrackingController {
+	return &TrackingController{DB: db}
+}
+
+// TrackParcel godoc
+// @Summary Look up a tracking number
+// @Description Get everything known about a tracking number in one response: the order with its details, its pick order, QC online and QC ribbon with boxes, outbound, returns (by old or new tracking number) and complains, with the station the parcel is currently at. The new tracking number of a return also finds the original order.
+// @Tags tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tracking path string true "Tracking number"
+// @Success 200 {object} utils.Response{data=TrackingLookupResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/track/{tracking} [get]
+func (tc *TrackingController) TrackParcel(c *gin.Context) {
+	tracking := strings.TrimSpace(c.Param("tracking"))
+
+	lookup, err := models.LookupTracking(tc.DB, tracking)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Tracking not found", "no station has a record of the specified tracking number")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to look up tracking", err.Error())
+		return
+	}
+
+	response := TrackingLookupResponse{
+		Tracking:       lookup.Tracking,
+		CurrentStation: lookup.CurrentStation(),
+		Returns:        models.ToReturnResponses(lookup.Returns),
+		Complains:      models.ToComplainResponses(lookup.Complains),
+	}
+	if lookup.Order != nil {
+		order := lookup.Order.ToOrderResponse()
+		response.Order = &order
+	}
+	if lookup.PickOrder != nil {
+		pickOrder := lookup.PickOrder.ToPickOrderResponse()
+		response.PickOrder = &pickOrder
+	}
+	if lookup.QcOnline != nil {
+		qcOnline := lookup.QcOnline.ToQcOnlineResponse()
+		response.QcOnline = &qcOnline
+	}
+	if lookup.QcRibbon != nil {
+		qcRibbon := lookup.QcRibbon.ToQcRibbonResponse()
+		response.QcRibbon = &qcRibbon
+	}
+	if lookup.Outbound != nil {
+		outbound := lookup.Outbound.ToOutboundResponse()
+		response.Outbound = &outbound
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tracking retrieved successfully", response)
+}
+
+// Request/Response structs
+type TrackingLookupResponse struct {
+	Tracking       string                    `json:"tracking"`
+	CurrentStation string                    `json:"current_station"`
+	Order          *models.OrderResponse     `json:"order"`
+	PickOrder      *models.PickOrderResponse `json:"pick_order"`
+	QcOnline       *models.QcOnlineResponse  `json:"qc_online"`
+	QcRibbon       *models.QcRibbonResponse  `json:"qc_ribbon"`
+	Outbound       *models.OutboundResponse  `json:"outbound"`
+	Returns        []models.ReturnResponse   `json:"returns"`
+	Complains      []models.ComplainResponse `json:"complains"`
+}
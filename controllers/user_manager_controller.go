@@ -142,8 +142,14 @@ func (ac *UserManagerController) UpdateUserStatus(c *gin.Context) {
 		return
 	}
 
+	before := user
 	user.IsActive = req.IsActive
-	if err := ac.DB.Save(&user).Error; err != nil {
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdateStatus, models.AuditEntityUser, user.ID, before, user)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update user status", err.Error())
 		return
 	}
@@ -228,13 +234,9 @@ func (ac *UserManagerController) AssignRole(c *gin.Context) {
 	}
 
 	// Assign role
-	userRole := models.UserRole{
-		UserID:     user.ID,
-		RoleID:     role.ID,
-		AssignedBy: currentUserID.(uint),
-	}
-
-	if err := ac.DB.Create(&userRole).Error; err != nil {
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		return assignUserRole(c, tx, user.ID, role, currentUserID.(uint))
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to assign role", err.Error())
 		return
 	}
@@ -294,15 +296,27 @@ func (ac *UserManagerController) RemoveRole(c *gin.Context) {
 		return
 	}
 
+	// Find target user
+	var user models.User
+	if err := ac.DB.First(&user, userID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found", err.Error())
+		return
+	}
+
 	// Remove role
-	if err := ac.DB.Where("user_id = ? AND role_id = ?", userID, role.ID).Delete(&models.UserRole{}).Error; err != nil {
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND role_id = ?", user.ID, role.ID).Delete(&models.UserRole{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return recordAudit(c, tx, models.AuditActionRemoveRole, models.AuditEntityUser, user.ID, auditRole(role), nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to remove role", err.Error())
 		return
 	}
 
 	// Reload user with updated roles
-	var user models.User
-	ac.DB.Preload("UserRoles.Role").Preload("UserRoles.Assigner").First(&user, userID)
+	ac.DB.Preload("UserRoles.Role").Preload("UserRoles.Assigner").First(&user, user.ID)
 
 	utils.SuccessResponse(c, http.StatusOK, "Successfully removed role from user", user.ToUserResponse())
 }
@@ -358,7 +372,12 @@ func (ac *UserManagerController) CreateUser(c *gin.Context) {
 		IsActive: req.IsActive,
 	}
 
-	if err := ac.DB.Create(&user).Error; err != nil {
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionCreate, models.AuditEntityUser, user.ID, nil, user)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user", err.Error())
 		return
 	}
@@ -396,13 +415,9 @@ func (ac *UserManagerController) CreateUser(c *gin.Context) {
 			return
 		}
 
-		userRole := models.UserRole{
-			UserID:     user.ID,
-			RoleID:     role.ID,
-			AssignedBy: currentUserID.(uint),
-		}
-
-		if err := ac.DB.Create(&userRole).Error; err != nil {
+		if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+			return assignUserRole(c, tx, user.ID, role, currentUserID.(uint))
+		}); err != nil {
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to assign role", err.Error())
 			return
 		}
@@ -410,12 +425,9 @@ func (ac *UserManagerController) CreateUser(c *gin.Context) {
 		// Assign guest role by default
 		var guestRole models.Role
 		if err := ac.DB.Where("name = ?", "guest").First(&guestRole).Error; err == nil {
-			userRole := models.UserRole{
-				UserID:     user.ID,
-				RoleID:     guestRole.ID,
-				AssignedBy: currentUserID.(uint),
-			}
-			ac.DB.Create(&userRole)
+			ac.DB.Transaction(func(tx *gorm.DB) error {
+				return assignUserRole(c, tx, user.ID, guestRole, currentUserID.(uint))
+			})
 		}
 	}
 
@@ -503,6 +515,12 @@ func (ac *UserManagerController) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	if err := recordAudit(c, tx, models.AuditActionDelete, models.AuditEntityUser, user.ID, user, nil); err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record audit log", err.Error())
+		return
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to commit transaction", err.Error())
@@ -576,17 +594,19 @@ func (ac *UserManagerController) UpdateUserPassword(c *gin.Context) {
 		return
 	}
 
-	// Update password
+	// Update password and clear refresh token to force re-login. The password itself never goes into the audit log.
 	user.Password = hashedPassword
-	if err := ac.DB.Save(&user).Error; err != nil {
+	user.RefreshToken = ""
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionResetPassword, models.AuditEntityUser, user.ID, nil, nil)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update password", err.Error())
 		return
 	}
 
-	// Clear refresh token to force re-login
-	user.RefreshToken = ""
-	ac.DB.Save(&user)
-
 	// Load user with roles for response
 	ac.DB.Preload("UserRoles.Role").Preload("UserRoles.Assigner").First(&user, user.ID)
 
@@ -660,6 +680,8 @@ func (ac *UserManagerController) UpdateUserProfile(c *gin.Context) {
 		return
 	}
 
+	before := user
+
 	// Update fields if provided
 	if req.Name != "" {
 		user.Name = req.Name
@@ -669,7 +691,12 @@ func (ac *UserManagerController) UpdateUserProfile(c *gin.Context) {
 	}
 
 	// Save changes
-	if err := ac.DB.Save(&user).Error; err != nil {
+	if err := ac.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		return recordAudit(c, tx, models.AuditActionUpdate, models.AuditEntityUser, user.ID, before, user)
+	}); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update user profile", err.Error())
 		return
 	}
@@ -727,6 +754,25 @@ func (ac *UserManagerController) GetRoles(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Successfully retrieved roles", response)
 }
 
+// assignUserRole gives a role to a user and records the assignment in the audit log
+func assignUserRole(c *gin.Context, tx *gorm.DB, userID uint, role models.Role, assignerID uint) error {
+	userRole := models.UserRole{
+		UserID:     userID,
+		RoleID:     role.ID,
+		AssignedBy: assignerID,
+	}
+
+	if err := tx.Create(&userRole).Error; err != nil {
+		return err
+	}
+	return recordAudit(c, tx, models.AuditActionAssignRole, models.AuditEntityUser, userID, nil, auditRole(role))
+}
+
+// auditRole is the role kept in the audit log of a role assignment or removal
+func auditRole(role models.Role) gin.H {
+	return gin.H{"role": role.Role}
+}
+
 // Request/Response structs
 type UsersListResponse struct {
 	Users      []models.UserResponse    `json:"users"`
@@ -0,0 +1,689 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"livo-backend-2.0/models"
+	"livo-backend-2.0/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxWebhookPayloadSize limits the size of a received webhook body
+const maxWebhookPayloadSize = 1 << 20
+
+type WebhookController struct {
+	DB *gorm.DB
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(db *gorm.DB) *WebhookController {
+	return &WebhookController{DB: db}
+}
+
+// ReceiveOrderWebhook godoc
+// @Summary Receive a marketplace order webhook
+// @Description Receive an order.created, order.updated or order.cancelled event in the Ginee order shape. The raw request body must be signed with the integration secret: the X-Webhook-Signature header carries the hex HMAC-SHA256 of the body, optionally prefixed with "sha256=". Every signed payload is recorded for replay.
+// @Description Orders are matched on order_ginee_id: a created or updated event creates the order when it does not exist yet, otherwise it updates the address, courier and tracking while the order is still "ready to pick". Events already processed with the same event_id are acknowledged as duplicates.
+// @Description To test locally, sign a payload file and post it, e.g. `SIG=$(openssl dgst -sha256 -hmac "$SECRET" -hex < order.json | sed 's/^.* //')` then `curl -X POST -H "Content-Type: application/json" -H "X-Webhook-Signature: sha256=$SIG" --data-binary @order.json http://localhost:8080/api/webhooks/orders/ginee`.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param code path string true "Integration code"
+// @Param X-Webhook-Signature header string true "Hex HMAC-SHA256 of the raw body"
+// @Param payload body OrderWebhookPayload true "Order event"
+// @Success 200 {object} utils.Response{data=models.WebhookEventResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/webhooks/orders/{code} [post]
+func (wc *WebhookController) ReceiveOrderWebhook(c *gin.Context) {
+	var integration models.WebhookIntegration
+	if err := wc.DB.Where("code = ? AND active = ?", c.Param("code"), true).First(&integration).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Integration not found", "no active integration found with the specified code")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find integration", err.Error())
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read payload", err.Error())
+		return
+	}
+
+	// Unsigned payloads are rejected before anything is recorded
+	if !utils.VerifyWebhookSignature(integration.Secret, payload, c.GetHeader(utils.WebhookSignatureHeader)) {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid signature", "the "+utils.WebhookSignatureHeader+" header does not match the payload")
+		return
+	}
+
+	// Record the payload as received, with the identifiers that can be read from it
+	event := models.WebhookEvent{
+		IntegrationID: integration.ID,
+		Payload:       string(payload),
+		Status:        models.WebhookStatusReceived,
+	}
+	var body OrderWebhookPayload
+	if json.Unmarshal(payload, &body) == nil {
+		event.EventID = strings.TrimSpace(body.EventID)
+		event.EventType = strings.TrimSpace(body.Event)
+		event.OrderGineeID = strings.TrimSpace(body.Data.OrderGineeID)
+	}
+	if err := wc.DB.Create(&event).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record webhook", err.Error())
+		return
+	}
+
+	// Redeliveries of an event that was already handled are acknowledged without processing it again
+	if event.EventID != "" {
+		var original models.WebhookEvent
+		err := wc.DB.Where("integration_id = ? AND event_id = ? AND id <> ? AND status IN ?", integration.ID, event.EventID, event.ID,
+			[]string{models.WebhookStatusProcessed, models.WebhookStatusIgnored}).
+			Order("id ASC").First(&original).Error
+		if err == nil {
+			now := time.Now()
+			event.Status = models.WebhookStatusDuplicate
+			event.Result = fmt.Sprintf("event already handled by webhook event %d", original.ID)
+			event.OrderID = original.OrderID
+			event.ProcessedAt = &now
+			if err := wc.DB.Save(&event).Error; err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record webhook", err.Error())
+				return
+			}
+			utils.SuccessResponse(c, http.StatusOK, "Webhook already processed", event.ToWebhookEventResponse(false))
+			return
+		}
+		if err != gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to check webhook event", err.Error())
+			return
+		}
+	}
+
+	wc.respondWithProcessedEvent(c, &integration, &event)
+}
+
+// GetWebhookIntegrations godoc
+// @Summary Get webhook integrations
+// @Description Get the list of marketplace integrations allowed to push order webhooks. Secrets are never listed.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.WebhookIntegrationResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/webhook-integrations [get]
+func (wc *WebhookController) GetWebhookIntegrations(c *gin.Context) {
+	var integrations []models.WebhookIntegration
+	if err := wc.DB.Preload("Creator").Order("code ASC").Find(&integrations).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve integrations", err.Error())
+		return
+	}
+
+	response := make([]models.WebhookIntegrationResponse, len(integrations))
+	for i, integration := range integrations {
+		response[i] = integration.ToWebhookIntegrationResponse()
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Integrations retrieved successfully", response)
+}
+
+// CreateWebhookIntegration godoc
+// @Summary Create a webhook integration
+// @Description Create a marketplace integration with a generated signing secret. The secret is only returned once; rotate it if it is lost.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param integration body CreateWebhookIntegrationRequest true "Integration data"
+// @Success 201 {object} utils.Response{data=WebhookIntegrationSecretResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /api/webhook-integrations [post]
+func (wc *WebhookController) CreateWebhookIntegration(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exist := c.Get("user_id")
+	if !exist {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", "User not authenticated")
+		return
+	}
+
+	creatorID, ok := userID.(uint)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Invalid user ID", "Failed to convert user ID")
+		return
+	}
+
+	var req CreateWebhookIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if code == "" || strings.ContainsAny(code, " /?#") {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid integration code", "code must not be empty or contain spaces, '/', '?' or '#'")
+		return
+	}
+
+	// Check if the code is already used, including removed integrations
+	var existing models.WebhookIntegration
+	if err := wc.DB.Unscoped().Where("code = ?", code).First(&existing).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Integration already exists", "an integration with this code already exists")
+		return
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate secret", err.Error())
+		return
+	}
+
+	integration := models.WebhookIntegration{
+		Code:      code,
+		Name:      strings.TrimSpace(req.Name),
+		Secret:    secret,
+		Active:    true,
+		CreatorID: creatorID,
+	}
+	if err := wc.DB.Create(&integration).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create integration", err.Error())
+		return
+	}
+
+	wc.DB.Preload("Creator").First(&integration, integration.ID)
+
+	utils.SuccessResponse(c, http.StatusCreated, "Integration created successfully", WebhookIntegrationSecretResponse{
+		Integration: integration.ToWebhookIntegrationResponse(),
+		Secret:      secret,
+	})
+}
+
+// UpdateWebhookIntegration godoc
+// @Summary Update a webhook integration
+// @Description Update the name of an integration or enable/disable it. Webhooks of a disabled integration are rejected.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Integration ID"
+// @Param integration body UpdateWebhookIntegrationRequest true "Integration data"
+// @Success 200 {object} utils.Response{data=models.WebhookIntegrationResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/webhook-integrations/{id} [put]
+func (wc *WebhookController) UpdateWebhookIntegration(c *gin.Context) {
+	var req UpdateWebhookIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	integration, ok := wc.findWebhookIntegration(c)
+	if !ok {
+		return
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		integration.Name = name
+	}
+	if req.Active != nil {
+		integration.Active = *req.Active
+	}
+
+	if err := wc.DB.Omit(clause.Associations).Save(&integration).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update integration", err.Error())
+		return
+	}
+
+	wc.DB.Preload("Creator").First(&integration, integration.ID)
+
+	utils.SuccessResponse(c, http.StatusOK, "Integration updated successfully", integration.ToWebhookIntegrationResponse())
+}
+
+// RotateWebhookIntegrationSecret godoc
+// @Summary Rotate a webhook integration secret
+// @Description Generate a new signing secret for an integration. The previous secret stops working immediately and the new one is only returned once.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Integration ID"
+// @Success 200 {object} utils.Response{data=WebhookIntegrationSecretResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/webhook-integrations/{id}/rotate-secret [put]
+func (wc *WebhookController) RotateWebhookIntegrationSecret(c *gin.Context) {
+	integration, ok := wc.findWebhookIntegration(c)
+	if !ok {
+		return
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate secret", err.Error())
+		return
+	}
+
+	integration.Secret = secret
+	if err := wc.DB.Omit(clause.Associations).Save(&integration).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to rotate secret", err.Error())
+		return
+	}
+
+	wc.DB.Preload("Creator").First(&integration, integration.ID)
+
+	utils.SuccessResponse(c, http.StatusOK, "Secret rotated successfully", WebhookIntegrationSecretResponse{
+		Integration: integration.ToWebhookIntegrationResponse(),
+		Secret:      secret,
+	})
+}
+
+// GetWebhookEvents godoc
+// @Summary Get received webhook events
+// @Description Get the received webhook payloads, newest first, with pagination and optional integration, status, event type, order and date range filtering. Payloads are only included in the event detail.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param integration query string false "Filter by integration code"
+// @Param status query string false "Filter by status (received, processed, ignored, duplicate, failed)"
+// @Param event query string false "Filter by event type (order.created, order.updated, order.cancelled)"
+// @Param order_ginee_id query string false "Filter by Ginee order ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD format)"
+// @Param end_date query string false "End date (YYYY-MM-DD format)"
+// @Success 200 {object} utils.Response{data=WebhookEventsListResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /api/webhook-events [get]
+func (wc *WebhookController) GetWebhookEvents(c *gin.Context) {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset := (page - 1) * limit
+
+	var events []models.WebhookEvent
+	var total int64
+
+	query := wc.DB.Model(&models.WebhookEvent{})
+
+	query, ok := applyDateRange(c, query, "created_at")
+	if !ok {
+		return
+	}
+
+	if code := strings.TrimSpace(c.Query("integration")); code != "" {
+		query = query.Where("integration_id IN (?)", wc.DB.Unscoped().Model(&models.WebhookIntegration{}).Select("id").Where("code = ?", code))
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if eventType := c.Query("event"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	if orderGineeID := strings.TrimSpace(c.Query("order_ginee_id")); orderGineeID != "" {
+		query = query.Where("order_ginee_id = ?", orderGineeID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count webhook events", err.Error())
+		return
+	}
+
+	if err := query.Preload("Integration", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve webhook events", err.Error())
+		return
+	}
+
+	response := WebhookEventsListResponse{
+		Events: make([]models.WebhookEventResponse, len(events)),
+		Pagination: utils.PaginationResponse{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}
+	for i, event := range events {
+		response.Events[i] = event.ToWebhookEventResponse(false)
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook events retrieved successfully", response)
+}
+
+// GetWebhookEvent godoc
+// @Summary Get a received webhook event
+// @Description Get a received webhook event with its payload as received.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook event ID"
+// @Success 200 {object} utils.Response{data=models.WebhookEventResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /api/webhook-events/{id} [get]
+func (wc *WebhookController) GetWebhookEvent(c *gin.Context) {
+	event, ok := wc.findWebhookEvent(c)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook event retrieved successfully", event.ToWebhookEventResponse(true))
+}
+
+// ReplayWebhookEvent godoc
+// @Summary Replay a received webhook event
+// @Description Process the stored payload of a webhook event again, e.g. after fixing the data that made it fail. The signature is not checked again and the duplicate check is skipped; the order rules are the same as for a received webhook. Events of disabled or deleted integrations cannot be replayed.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook event ID"
+// @Success 200 {object} utils.Response{data=models.WebhookEventResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /api/webhook-events/{id}/replay [put]
+func (wc *WebhookController) ReplayWebhookEvent(c *gin.Context) {
+	event, ok := wc.findWebhookEvent(c)
+	if !ok {
+		return
+	}
+
+	// Replays follow the same rule as received webhooks: only active integrations push orders
+	if event.Integration == nil || event.Integration.DeletedAt.Valid {
+		utils.ErrorResponse(c, http.StatusConflict, "Integration deleted", "events of a deleted integration can no longer be replayed")
+		return
+	}
+	if !event.Integration.Active {
+		utils.ErrorResponse(c, http.StatusConflict, "Integration disabled", "enable the integration before replaying its events")
+		return
+	}
+
+	event.Replays++
+	wc.respondWithProcessedEvent(c, event.Integration, &event)
+}
+
+// findWebhookIntegration finds the integration from the id path parameter and responds when it cannot
+func (wc *WebhookController) findWebhookIntegration(c *gin.Context) (models.WebhookIntegration, bool) {
+	var integration models.WebhookIntegration
+	if err := wc.DB.First(&integration, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Integration not found", "no integration found with the specified ID")
+			return integration, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find integration", err.Error())
+		return integration, false
+	}
+	return integration, true
+}
+
+// findWebhookEvent finds the event from the id path parameter with its integration and responds when it cannot
+func (wc *WebhookController) findWebhookEvent(c *gin.Context) (models.WebhookEvent, bool) {
+	var event models.WebhookEvent
+	if err := wc.DB.Preload("Integration", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).First(&event, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Webhook event not found", "no webhook event found with the specified ID")
+			return event, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to find webhook event", err.Error())
+		return event, false
+	}
+	return event, true
+}
+
+// respondWithProcessedEvent processes the event, records its outcome and responds with it
+func (wc *WebhookController) respondWithProcessedEvent(c *gin.Context, integration *models.WebhookIntegration, event *models.WebhookEvent) {
+	statusCode, err := wc.processOrderWebhook(integration, event)
+
+	now := time.Now()
+	event.ProcessedAt = &now
+	if err != nil {
+		event.Status = models.WebhookStatusFailed
+		event.Result = err.Error()
+	}
+	if saveErr := wc.DB.Omit(clause.Associations).Save(event).Error; saveErr != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record webhook", saveErr.Error())
+		return
+	}
+
+	if err != nil {
+		utils.ErrorResponse(c, statusCode, "Failed to process webhook", err.Error())
+		return
+	}
+
+	message := "Webhook processed successfully"
+	if event.Status == models.WebhookStatusIgnored {
+		message = "Webhook ignored"
+	}
+	utils.SuccessResponse(c, http.StatusOK, message, event.ToWebhookEventResponse(false))
+}
+
+// processOrderWebhook applies the stored payload of the event to the order and sets the status, result and order of
+// the event. Orders created by a webhook are imported on behalf of the integration creator. On failure it returns
+// the HTTP status to answer the sender with; the sender is expected to retry on non-2xx responses.
+func (wc *WebhookController) processOrderWebhook(integration *models.WebhookIntegration, event *models.WebhookEvent) (int, error) {
+	var payload OrderWebhookPayload
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return http.StatusUnprocessableEntity, fmt.Errorf("invalid payload: %s", err.Error())
+	}
+
+	event.EventID = strings.TrimSpace(payload.EventID)
+	event.EventType = strings.TrimSpace(payload.Event)
+	event.OrderGineeID = strings.TrimSpace(payload.Data.OrderGineeID)
+	payload.Data.OrderGineeID = event.OrderGineeID
+
+	if event.OrderGineeID == "" {
+		return http.StatusUnprocessableEntity, fmt.Errorf("data.order_ginee_id is required")
+	}
+	switch event.EventType {
+	case models.WebhookEventOrderCreated, models.WebhookEventOrderUpdated, models.WebhookEventOrderCancelled:
+	default:
+		return http.StatusUnprocessableEntity, fmt.Errorf("unsupported event '%s'", event.EventType)
+	}
+
+	statusCode := http.StatusInternalServerError
+	err := wc.DB.Transaction(func(tx *gorm.DB) error {
+		// Serialize events of the same order so concurrent deliveries cannot create it twice
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "webhook_order:"+event.OrderGineeID).Error; err != nil {
+			return err
+		}
+
+		var order models.Order
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_ginee_id = ?", event.OrderGineeID).First(&order).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		exists := err == nil
+
+		if exists {
+			orderID := order.ID
+			event.OrderID = &orderID
+			if order.DeletedAt.Valid {
+				return ignoreWebhookEvent(event, "order was deleted")
+			}
+		}
+
+		if event.EventType == models.WebhookEventOrderCancelled {
+			if !exists {
+				return ignoreWebhookEvent(event, "order not found")
+			}
+			if order.Status == models.OrderStatusCancelled {
+				return ignoreWebhookEvent(event, "order is already cancelled")
+			}
+			if !order.CanTransitionTo(models.OrderStatusCancelled) {
+				return ignoreWebhookEvent(event, fmt.Sprintf("order with status '%s' cannot be cancelled", order.Status))
+			}
+
+			reason := strings.TrimSpace(payload.Data.CancelReason)
+			if reason == "" {
+				reason = "Cancelled by " + integration.Name
+			}
+			if _, err := cancelOrder(tx, &order, nil, reason, models.OrderStationWebhook); err != nil {
+				if err == errOrderInManifest {
+					return ignoreWebhookEvent(event, err.Error())
+				}
+				return err
+			}
+
+			event.Status = models.WebhookStatusProcessed
+			event.Result = "order cancelled"
+			return nil
+		}
+
+		// Created and updated events create the order when it does not exist yet
+		if !exists {
+			if errs := validateImportedOrder(&payload.Data.CreateOrderRequest); len(errs) > 0 {
+				statusCode = http.StatusUnprocessableEntity
+				return fmt.Errorf("invalid order: %s", strings.Join(errs, "; "))
+			}
+
+			order, err := newGineeOrder(payload.Data.CreateOrderRequest, &integration.CreatorID)
+			if err != nil {
+				statusCode = http.StatusUnprocessableEntity
+				return err
+			}
+			if err := checkWebhookTracking(tx, order.Tracking, 0, &statusCode); err != nil {
+				return err
+			}
+			if err := createOrderWithDetails(tx, &order, models.OrderStationWebhook, "Created by "+integration.Name+" webhook"); err != nil {
+				return err
+			}
+
+			orderID := order.ID
+			event.OrderID = &orderID
+			event.Status = models.WebhookStatusProcessed
+			event.Result = "order created"
+			return nil
+		}
+
+		// Existing orders only take address, courier and tracking changes while they are still ready to pick
+		var changed []string
+		before, after := map[string]string{}, map[string]string{}
+		apply := func(field string, target *string, value string) {
+			if value = strings.TrimSpace(value); value != "" && value != *target {
+				before[field], after[field] = *target, value
+				*target = value
+				changed = append(changed, field)
+			}
+		}
+		current := order
+		apply("address", &current.Address, payload.Data.Address)
+		apply("courier", &current.Courier, payload.Data.Courier)
+		apply("tracking", &current.Tracking, payload.Data.Tracking)
+
+		if len(changed) == 0 {
+			return ignoreWebhookEvent(event, "no changes")
+		}
+		if order.Status != models.OrderStatusReadyToPick {
+			return ignoreWebhookEvent(event, fmt.Sprintf("order with status '%s' cannot be updated, changes to %s were not applied", order.Status, strings.Join(changed, ", ")))
+		}
+		if current.Tracking != order.Tracking {
+			if err := checkWebhookTracking(tx, current.Tracking, order.ID, &statusCode); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&current).Error; err != nil {
+			return err
+		}
+		orderEvent, err := models.NewOrderEvent(order.ID, models.OrderEventUpdated, models.OrderStationWebhook, before, after, nil, "Updated by "+integration.Name+" webhook")
+		if err != nil {
+			return err
+		}
+		if err := models.RecordOrderEvents(tx, orderEvent); err != nil {
+			return err
+		}
+
+		event.Status = models.WebhookStatusProcessed
+		event.Result = "order updated: " + strings.Join(changed, ", ")
+		return nil
+	})
+
+	return statusCode, err
+}
+
+// ignoreWebhookEvent marks the event as ignored with the reason; ignored events are acknowledged to the sender
+func ignoreWebhookEvent(event *models.WebhookEvent, reason string) error {
+	event.Status = models.WebhookStatusIgnored
+	event.Result = reason
+	return nil
+}
+
+// checkWebhookTracking fails with a conflict when the tracking number is used by another order, including removed orders
+func checkWebhookTracking(tx *gorm.DB, tracking string, orderID uint, statusCode *int) error {
+	var existing models.Order
+	err := tx.Unscoped().Where("tracking = ? AND id <> ?", tracking, orderID).First(&existing).Error
+	if err == nil {
+		*statusCode = http.StatusConflict
+		return fmt.Errorf("tracking '%s' is already used by order '%s'", tracking, existing.OrderGineeID)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+	return nil
+}
+
+// Request/Response structs
+type OrderWebhookPayload struct {
+	Event   string           `json:"event" example:"order.created"`
+	EventID string           `json:"event_id" example:"evt_2509116GA36VM5_1"`
+	Data    OrderWebhookData `json:"data"`
+}
+
+// OrderWebhookData is the order in the Ginee shape; cancel_reason is only read for order.cancelled events
+type OrderWebhookData struct {
+	CreateOrderRequest
+	CancelReason string `json:"cancel_reason" example:"Cancelled by buyer"`
+}
+
+type CreateWebhookIntegrationRequest struct {
+	Code string `json:"code" binding:"required" example:"ginee"`
+	Name string `json:"name" binding:"required" example:"Ginee"`
+}
+
+type UpdateWebhookIntegrationRequest struct {
+	Name   string `json:"name" example:"Ginee"`
+	Active *bool  `json:"active" example:"true"`
+}
+
+type WebhookIntegrationSecretResponse struct {
+	Integration models.WebhookIntegrationResponse `json:"integration"`
+	Secret      string                            `json:"secret"`
+}
+
+type WebhookEventsListResponse struct {
+	Events     []models.WebhookEventResponse `json:"events"`
+	Pagination utils.PaginationResponse      `json:"pagination"`
+}
@@ -0,0 +1,290 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"livo-backend-2.0/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// pollInterval is how often idle workers look for queued jobs
+	pollInterval = 2 * time.Second
+	// heartbeatInterval is how often a running job saves its progress and checks for cancellation
+	heartbeatInterval = 2 * time.Second
+	// staleAfter is how long a running job may go without heartbeat before it is considered abandoned by a stopped worker
+	staleAfter = 2 * time.Minute
+	// retryDelay is the delay before the first retry; later retries wait longer
+	retryDelay = 30 * time.Second
+)
+
+// Handler runs a job of one type. It reports its progress and outcome on the task and returns an error when the
+// job failed. Failed jobs are retried until they run out of attempts, unless the error is permanent. Handlers must
+// stop when ctx is done, which happens when the job is cancelled.
+type Handler func(ctx context.Context, task *Task) error
+
+// Runner runs queued jobs from the jobs table in background workers. Jobs are claimed with SKIP LOCKED, so several
+// instances of the service can share the table.
+type Runner struct {
+	db        *gorm.DB
+	workers   int
+	retention time.Duration
+	handlers  map[string]Handler
+}
+
+// NewRunner creates a new job runner with the given number of workers. Finished jobs are kept for retention, so
+// their result and file can be fetched, and are then deleted by PurgeFinishedJobs.
+func NewRunner(db *gorm.DB, workers int, retention time.Duration) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{db: db, workers: workers, retention: retention, handlers: make(map[string]Handler)}
+}
+
+// Register sets the handler of a job type. Jobs of types without handler are left queued.
+func (r *Runner) Register(jobType string, handler Handler) {
+	r.handlers[jobType] = handler
+}
+
+// Start starts the workers in the background. They stop when ctx is done.
+func (r *Runner) Start(ctx context.Context) {
+	for i := 0; i < r.workers; i++ {
+		go r.work(ctx, i == 0)
+	}
+}
+
+// work claims and runs jobs until ctx is done. The janitor worker also requeues abandoned jobs.
+func (r *Runner) work(ctx context.Context, janitor bool) {
+	for {
+		if janitor {
+			if err := r.requeueStaleJobs(); err != nil {
+				log.Printf("⚠️  Failed to requeue stale jobs: %v", err)
+			}
+		}
+
+		job, err := r.claim()
+		if err != nil {
+			log.Printf("⚠️  Failed to claim job: %v", err)
+		}
+		if job != nil {
+			r.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// claim marks the next due job as running and returns it, or nil when there is none
+func (r *Runner) claim() (*models.Job, error) {
+	if len(r.handlers) == 0 {
+		return nil, nil
+	}
+	jobTypes := make([]string, 0, len(r.handlers))
+	for jobType := range r.handlers {
+		jobTypes = append(jobTypes, jobType)
+	}
+
+	var job models.Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ? AND type IN ?", models.JobStatusQueued, time.Now(), jobTypes).
+			Order("run_at ASC, id ASC").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = models.JobStatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.HeartbeatAt = &now
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":       job.Status,
+			"attempts":     job.Attempts,
+			"started_at":   now,
+			"heartbeat_at": now,
+		}).Error
+	})
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// PurgeFinishedJobs deletes the jobs that finished longer than the retention ago, with their params, result and file.
+// It is safe to run on every instance of the service.
+func (r *Runner) PurgeFinishedJobs(ctx context.Context) error {
+	result := r.db.WithContext(ctx).
+		Where("status IN ? AND finished_at < ?", []string{models.JobStatusSucceeded, models.JobStatusFailed, models.JobStatusCancelled}, time.Now().Add(-r.retention)).
+		Delete(&models.Job{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("✓ Deleted %d finished jobs older than %s", result.RowsAffected, r.retention)
+	}
+	return nil
+}
+
+// requeueStaleJobs requeues running jobs whose worker stopped sending heartbeats, or fails them when they are out of attempts
+func (r *Runner) requeueStaleJobs() error {
+	now := time.Now()
+	stale := r.db.Model(&models.Job{}).Where("status = ? AND heartbeat_at < ?", models.JobStatusRunning, now.Add(-staleAfter)).Session(&gorm.Session{})
+
+	if err := stale.Where("attempts >= max_attempts").Updates(map[string]interface{}{
+		"status":      models.JobStatusFailed,
+		"error":       "the worker running the job stopped",
+		"finished_at": now,
+	}).Error; err != nil {
+		return err
+	}
+
+	return stale.Updates(map[string]interface{}{
+		"status": models.JobStatusQueued,
+		"error":  "the worker running the job stopped",
+		"run_at": now,
+	}).Error
+}
+
+// run runs the handler of the job and records its outcome
+func (r *Runner) run(ctx context.Context, job *models.Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	task := &Task{Job: job}
+	stopHeartbeat := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		r.heartbeat(task, cancel, stopHeartbeat)
+	}()
+
+	err := runHandler(jobCtx, r.handlers[job.Type], task)
+
+	close(stopHeartbeat)
+	<-heartbeatDone
+
+	if err := r.finish(task, err); err != nil {
+		log.Printf("⚠️  Failed to record outcome of job %d: %v", job.ID, err)
+	}
+}
+
+// runHandler runs the handler, turning a panic into a permanent error
+func runHandler(ctx context.Context, handler Handler, task *Task) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = Permanent(fmt.Errorf("job panicked: %v", recovered))
+		}
+	}()
+	return handler(ctx, task)
+}
+
+// heartbeat saves the progress of the task until stop is closed and cancels the job when cancellation is requested
+func (r *Runner) heartbeat(task *Task, cancel context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		progress, total := task.counts()
+		result := r.claimed(task.Job).Updates(map[string]interface{}{
+			"progress":     progress,
+			"total":        total,
+			"heartbeat_at": time.Now(),
+		})
+		if result.Error != nil {
+			log.Printf("⚠️  Failed to save progress of job %d: %v", task.Job.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			// The job was requeued as stale and may run on another worker, this run must not go on
+			log.Printf("⚠️  Job %d is no longer claimed by this worker, stopping it", task.Job.ID)
+			cancel()
+			return
+		}
+
+		var current models.Job
+		if err := r.db.Select("id", "cancel_requested").First(&current, task.Job.ID).Error; err == nil && current.CancelRequested {
+			task.markCancelled()
+			cancel()
+		}
+	}
+}
+
+// finish records the outcome of the job: succeeded, cancelled, queued again for a retry or failed.
+// A job that completed before it noticed its cancellation is recorded as succeeded.
+func (r *Runner) finish(task *Task, err error) error {
+	job := task.Job
+	now := time.Now()
+	progress, total := task.counts()
+
+	updates := map[string]interface{}{
+		"progress":     progress,
+		"total":        total,
+		"heartbeat_at": now,
+	}
+	if result, ok := task.result(); ok {
+		updates["result"] = result
+	}
+
+	switch {
+	case err == nil:
+		updates["status"] = models.JobStatusSucceeded
+		updates["error"] = nil
+		updates["finished_at"] = now
+		if file, ok := task.file(); ok {
+			updates["file_name"] = file.name
+			updates["content_type"] = file.contentType
+			updates["file"] = file.data
+		}
+	case task.isCancelled():
+		updates["status"] = models.JobStatusCancelled
+		updates["finished_at"] = now
+		if !errors.Is(err, context.Canceled) {
+			updates["error"] = err.Error()
+		}
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		updates["status"] = models.JobStatusFailed
+		updates["error"] = err.Error()
+		updates["finished_at"] = now
+	default:
+		updates["status"] = models.JobStatusQueued
+		updates["error"] = err.Error()
+		updates["run_at"] = now.Add(retryDelay * time.Duration(job.Attempts*job.Attempts))
+	}
+
+	result := r.claimed(job).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("the job is no longer claimed by this worker, its outcome is dropped")
+	}
+	return nil
+}
+
+// claimed scopes an update to the job as long as it is still running the attempt this worker claimed, so a worker
+// whose job was requeued as stale never overwrites the run that took over
+func (r *Runner) claimed(job *models.Job) *gorm.DB {
+	return r.db.Model(&models.Job{}).Where("id = ? AND status = ? AND attempts = ?", job.ID, models.JobStatusRunning, job.Attempts)
+}
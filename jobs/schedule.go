@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs task in the background every interval until ctx is done. Failed runs are logged and the task runs
+// again at the next interval. Unlike queued jobs, scheduled tasks run on every instance of the service, so they
+// must be safe to run concurrently.
+func Every(ctx context.Context, interval time.Duration, name string, task func(ctx context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := task(ctx); err != nil {
+				log.Printf("⚠️  Scheduled task %s failed: %v", name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
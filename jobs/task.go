@@ -0,0 +1,130 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"livo-backend-2.0/models"
+)
+
+// Task is a running job as seen by its handler
+type Task struct {
+	Job *models.Job
+
+	mu        sync.Mutex
+	progress  int
+	total     int
+	resultSet bool
+	resultRaw string
+	output    *taskFile
+	cancelled bool
+}
+
+type taskFile struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+// DecodeParams decodes the JSON params of the job into v. Params that cannot be decoded fail the job permanently.
+func (t *Task) DecodeParams(v interface{}) error {
+	if err := json.Unmarshal([]byte(t.Job.Params), v); err != nil {
+		return Permanent(fmt.Errorf("invalid job params: %s", err.Error()))
+	}
+	return nil
+}
+
+// SetTotal sets the amount of work of the job, e.g. the number of orders to import
+func (t *Task) SetTotal(total int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = total
+}
+
+// SetProgress sets the amount of work done. Progress is saved periodically while the job runs.
+func (t *Task) SetProgress(progress int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress = progress
+}
+
+// SetResult sets the JSON result of the job. The result is kept whatever the outcome, so a failed or cancelled job
+// can report what it did.
+func (t *Task) SetResult(v interface{}) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resultSet = true
+	t.resultRaw = string(encoded)
+	return nil
+}
+
+// SetFile sets the file produced by the job, to be downloaded once the job succeeded
+func (t *Task) SetFile(name, contentType string, data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.output = &taskFile{name: name, contentType: contentType, data: data}
+}
+
+func (t *Task) counts() (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progress, t.total
+}
+
+func (t *Task) result() (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.resultRaw, t.resultSet
+}
+
+func (t *Task) file() (*taskFile, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.output, t.output != nil
+}
+
+func (t *Task) markCancelled() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cancelled = true
+}
+
+func (t *Task) isCancelled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cancelled
+}
+
+// permanentError is a job error that retrying will not fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as permanent so the job fails without being retried
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent checks whether the error was marked as permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
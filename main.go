@@ -1,16 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
+	_ "time/tzdata" // Embedded timezone database, the business timezone must load on hosts without one
 
 	"livo-backend-2.0/config"
 	"livo-backend-2.0/controllers"
 	_ "livo-backend-2.0/docs" // This is required for Swagger
+	"livo-backend-2.0/jobs"
 	"livo-backend-2.0/migrations"
+	"livo-backend-2.0/models"
 	"livo-backend-2.0/routes"
+	"livo-backend-2.0/utils"
 )
 
+// holidayRefreshInterval is how often holidays changed on another instance are picked up
+const holidayRefreshInterval = 15 * time.Minute
+
+// jobPurgeInterval is how often finished jobs past their retention are deleted
+const jobPurgeInterval = time.Hour
+
 // @title Livotech Backend Service
 // @version 2.0
 // @description Comprehensive backend service for Livotech platform with JWT authentication and role-based access control. Authentication: This endpoint uses Bearer token authentication. Include your JWT token in the Authorization header in the format: Bearer your-access-token
@@ -29,6 +41,14 @@ func main() {
 	cfg := config.LoadConfig()
 	log.Println("✓ Configuration loaded successfully")
 
+	// Set up the warehouse calendar before anything reads the time
+	calendar, err := utils.NewCalendar(cfg.BusinessTimezone, cfg.WorkingDays, cfg.WorkingHours)
+	if err != nil {
+		log.Fatal("❌ Invalid business calendar:", err)
+	}
+	utils.SetBusinessCalendar(calendar)
+	log.Printf("✓ Business timezone %s, working hours %s", cfg.BusinessTimezone, cfg.WorkingHours)
+
 	// Connect to database with retry logic
 	log.Println("🔌 Connecting to database...")
 	config.ConnectDatabase(cfg)
@@ -47,13 +67,53 @@ func main() {
 	expeditionController := controllers.NewExpeditionController(db)
 	storeController := controllers.NewStoreController(db)
 	orderController := controllers.NewOrderController(db)
+	pickOrderController := controllers.NewPickOrderController(db)
+	pickWaveController := controllers.NewPickWaveController(db)
+	qcOnlineController := controllers.NewQcOnlineController(db)
+	qcRibbonController := controllers.NewQcRibbonController(db)
+	outboundController := controllers.NewOutboundController(db)
+	manifestController := controllers.NewManifestController(db)
+	complainController := controllers.NewComplainController(db)
+	feeChargeController := controllers.NewFeeChargeController(db)
+	returnController := controllers.NewReturnController(db)
+	productController := controllers.NewProductController(db)
+	stockController := controllers.NewStockController(db)
+	stockOpnameController := controllers.NewStockOpnameController(db)
+	webhookController := controllers.NewWebhookController(db)
+	jobController := controllers.NewJobController(db)
+	holidayController := controllers.NewHolidayController(db)
+	auditLogController := controllers.NewAuditLogController(db)
+	trackingController := controllers.NewTrackingController(db)
 	log.Println("✓ Controllers initialized successfully")
 
 	// Setup routes
 	log.Println("🛣️  Setting up routes...")
-	router := routes.SetupRoutes(cfg, authController, userManagerController, boxController, channelController, expeditionController, storeController, orderController)
+	router := routes.SetupRoutes(cfg, authController, userManagerController, boxController, channelController, expeditionController, storeController, orderController, pickOrderController, pickWaveController, qcOnlineController, qcRibbonController, outboundController, manifestController, complainController, feeChargeController, returnController, productController, stockController, stockOpnameController, webhookController, jobController, holidayController, auditLogController, trackingController)
 	log.Println("✓ Routes configured successfully")
 
+	// Start background job runner
+	log.Println("⚙️  Starting job runner...")
+	jobRunner := jobs.NewRunner(db, cfg.JobWorkers, cfg.JobRetention)
+	jobRunner.Register(models.JobTypeBulkCreateOrders, orderController.RunBulkCreateOrdersJob)
+	jobRunner.Register(models.JobTypeImportGineeOrders, orderController.RunImportGineeOrdersJob)
+	jobRunner.Register(models.JobTypeExportFeeCharges, feeChargeController.RunExportFeeChargesJob)
+	jobRunner.Register(models.JobTypeExportStockOpname, stockOpnameController.RunExportStockOpnameJob)
+	jobRunner.Register(models.JobTypeExportManifest, manifestController.RunExportManifestJob)
+	jobRunner.Register(models.JobTypeExportPickList, pickWaveController.RunExportPickListJob)
+	jobRunner.Register(models.JobTypeImportProducts, productController.RunImportProductsJob)
+	jobRunner.Start(context.Background())
+	log.Printf("✓ Job runner started with %d workers", cfg.JobWorkers)
+
+	// Delete finished jobs with their files once they are past retention
+	jobs.Every(context.Background(), jobPurgeInterval, "finished jobs cleanup", jobRunner.PurgeFinishedJobs)
+
+	// Keep holidays of the warehouse calendar up to date
+	jobs.Every(context.Background(), holidayRefreshInterval, "holiday calendar refresh", holidayController.RefreshCalendar)
+
+	// Start processing limit SLA checker
+	jobs.Every(context.Background(), cfg.SlaCheckInterval, "SLA check", orderController.CheckOrderSla)
+	log.Printf("✓ SLA checker runs every %s", cfg.SlaCheckInterval)
+
 	// Build API URL from config
 	apiURL := fmt.Sprintf("http://%s:%s", cfg.APIHost, cfg.Port)
 
@@ -119,6 +119,21 @@ func RequirePackingRole() gin.HandlerFunc {
 	return RequireRoles("superadmin", "coordinator", "packing")
 }
 
+// RequireReturnRole middleware for return intake endpoints
+func RequireReturnRole() gin.HandlerFunc {
+	return RequireRoles("superadmin", "coordinator", "admin-retur")
+}
+
+// RequireStockCountRole middleware for warehouse staff submitting stock opname counts
+func RequireStockCountRole() gin.HandlerFunc {
+	return RequireRoles("superadmin", "coordinator", "admin", "picker")
+}
+
+// RequireStockApprovalRole middleware for stock opname sessions and count approval
+func RequireStockApprovalRole() gin.HandlerFunc {
+	return RequireRoles("superadmin", "coordinator")
+}
+
 // RequireGuestRole middleware for guest-only endpoints
 func RequireGuestRole() gin.HandlerFunc {
 	return RequireRoles("guest")
@@ -2,23 +2,30 @@ package migrations
 
 import (
 	"log"
+	"time"
 
 	"livo-backend-2.0/models"
 	"livo-backend-2.0/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AutoMigrate runs database migrations
 func AutoMigrate(db *gorm.DB) {
+	// Stores created before stores had the ribbon flag get the default ribbon stores flagged once
+	flagRibbonStores := db.Migrator().HasTable(&models.Store{}) && !db.Migrator().HasColumn(&models.Store{}, "IsRibbon")
+
 	// Run migrations
 	err := db.AutoMigrate(
 		&models.Role{},
 		&models.User{},
 		&models.UserRole{},
 		&models.Product{},
+		&models.ProductBundleItem{},
 		&models.Order{},
 		&models.OrderDetail{},
+		&models.OrderEvent{},
 		&models.Box{},
 		&models.Channel{},
 		&models.Complain{},
@@ -26,6 +33,16 @@ func AutoMigrate(db *gorm.DB) {
 		&models.ComplainUserDetail{},
 		&models.Expedition{},
 		&models.Outbound{},
+		&models.Manifest{},
+		&models.Stock{},
+		&models.StockMovement{},
+		&models.StockOpname{},
+		&models.StockOpnameLine{},
+		&models.WebhookIntegration{},
+		&models.WebhookEvent{},
+		&models.Job{},
+		&models.Holiday{},
+		&models.AuditLog{},
 		&models.QcOnline{},
 		&models.QcOnlineDetail{},
 		&models.QcRibbon{},
@@ -35,6 +52,8 @@ func AutoMigrate(db *gorm.DB) {
 		&models.Store{},
 		&models.PickOrder{},
 		&models.PickOrderDetail{},
+		&models.PickWave{},
+		&models.DataMigration{},
 	)
 	if err != nil {
 		log.Printf("⚠️ Peringatan: Beberapa table gagal di-migrate: %v", err)
@@ -42,6 +61,9 @@ func AutoMigrate(db *gorm.DB) {
 		log.Println("✓ Migration berhasil dilakukan")
 	}
 
+	// Orders created before the business timezone had their processing limit read as UTC
+	runDataMigration(db, "shift_processing_limits_to_business_time", migrateProcessingLimitsToBusinessTime)
+
 	// Seed default roles
 	seedDefaultRoles(db)
 
@@ -58,7 +80,39 @@ func AutoMigrate(db *gorm.DB) {
 	seedDefaultExpeditions(db)
 
 	// Seed default stores
-	seedDefaultStores(db)
+	seedDefaultStores(db, flagRibbonStores)
+}
+
+// runDataMigration runs a one-time data migration together with its marker in one transaction, so it is applied
+// exactly once: a failed run is rolled back and retried on the next start, a finished run is never repeated.
+func runDataMigration(db *gorm.DB, name string, migrate func(tx *gorm.DB) error) {
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// The marker row is locked until the transaction ends, so concurrent instances wait and then skip
+		marker := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DataMigration{Name: name, AppliedAt: time.Now()})
+		if marker.Error != nil {
+			return marker.Error
+		}
+		if marker.RowsAffected == 0 {
+			return nil
+		}
+		return migrate(tx)
+	}); err != nil {
+		log.Printf("⚠️ Peringatan: Migrasi data %s gagal: %v", name, err)
+	}
+}
+
+// migrateProcessingLimitsToBusinessTime moves the processing limits of existing orders from the UTC wall clock they
+// were read as to the same wall clock in the business timezone. On a new database there are no orders to move.
+func migrateProcessingLimitsToBusinessTime(tx *gorm.DB) error {
+	_, offset := utils.BusinessNow().Zone()
+	result := tx.Unscoped().Model(&models.Order{}).
+		Where("processing_limit IS NOT NULL").
+		UpdateColumn("processing_limit", gorm.Expr("processing_limit - make_interval(secs => ?)", offset))
+	if result.Error != nil {
+		return result.Error
+	}
+	log.Printf("✓ Processing limit %d order dipindahkan ke zona waktu bisnis", result.RowsAffected)
+	return nil
 }
 
 // seedDefaultRoles creates default roles if they don't exist
@@ -90,10 +144,10 @@ func seedDefaultRoles(db *gorm.DB) {
 }
 
 // Seed default store data
-func seedDefaultStores(db *gorm.DB) {
+func seedDefaultStores(db *gorm.DB, flagRibbonStores bool) {
 	stores := []models.Store{
 		{Code: "AX", Name: "Axon"},
-		{Code: "DR", Name: "DeParcel Ribbon"},
+		{Code: "DR", Name: "DeParcel Ribbon", IsRibbon: true},
 		{Code: "AS", Name: "Axon Store"},
 		{Code: "AL", Name: "Aqualivo"},
 		{Code: "LM", Name: "Livo Mall"},
@@ -102,10 +156,10 @@ func seedDefaultStores(db *gorm.DB) {
 		{Code: "AI", Name: "Axon ID"},
 		{Code: "AM", Name: "Axon Mall"},
 		{Code: "AS", Name: "Aqualivo Store"},
-		{Code: "RP", Name: "Rumah Pita"},
+		{Code: "RP", Name: "Rumah Pita", IsRibbon: true},
 		{Code: "SL", Name: "Sporti Livo"},
 		{Code: "LT", Name: "Livotech"},
-		{Code: "BP", Name: "Bos Pita"},
+		{Code: "BP", Name: "Bos Pita", IsRibbon: true},
 	}
 
 	for _, store := range stores {
@@ -119,6 +173,16 @@ func seedDefaultStores(db *gorm.DB) {
 			}
 		}
 	}
+
+	// Flag the default ribbon stores on databases created before stores had the ribbon flag. This only runs
+	// on the migration that adds the flag, so stores unflagged by an admin stay unflagged.
+	if flagRibbonStores {
+		for _, store := range stores {
+			if store.IsRibbon {
+				db.Model(&models.Store{}).Where("code = ?", store.Code).Update("is_ribbon", true)
+			}
+		}
+	}
 }
 
 // Seed default expedition data
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Audit actions
+const (
+	AuditActionCreate        = "create"
+	AuditActionUpdate        = "update"
+	AuditActionDelete        = "delete"
+	AuditActionUpdateStatus  = "update_status"
+	AuditActionResetPassword = "reset_password"
+	AuditActionAssignRole    = "assign_role"
+	AuditActionRemoveRole    = "remove_role"
+)
+
+// Audited entities
+const (
+	AuditEntityBox        = "box"
+	AuditEntityChannel    = "channel"
+	AuditEntityExpedition = "expedition"
+	AuditEntityStore      = "store"
+	AuditEntityUser       = "user"
+)
+
+// auditIgnoredFields change on every save and say nothing about what was changed
+var auditIgnoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// AuditLog records a change to master data or user administration: who did what to which entity, from where
+type AuditLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ActorID   *uint     `gorm:"default:null;index" json:"actor_id"`
+	Action    string    `gorm:"not null;index" json:"action"`
+	Entity    string    `gorm:"not null;index:idx_audit_logs_entity" json:"entity"`
+	EntityID  uint      `gorm:"not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Diff      string    `gorm:"type:text;default:null" json:"diff"`
+	ClientIP  string    `gorm:"default:null" json:"client_ip"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	// Associations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+// AuditChange is the value of a field before and after a change
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type AuditLogResponse struct {
+	ID        uint            `json:"id"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  uint            `json:"entity_id"`
+	Diff      json.RawMessage `json:"diff,omitempty" swaggertype:"object"`
+	ClientIP  string          `json:"client_ip"`
+	ActorID   *uint           `json:"actor_id"`
+	Actor     string          `json:"actor"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ToAuditLogResponse converts AuditLog model to AuditLogResponse
+func (a *AuditLog) ToAuditLogResponse() AuditLogResponse {
+	response := AuditLogResponse{
+		ID:        a.ID,
+		Action:    a.Action,
+		Entity:    a.Entity,
+		EntityID:  a.EntityID,
+		ClientIP:  a.ClientIP,
+		ActorID:   a.ActorID,
+		Actor:     "System",
+		CreatedAt: a.CreatedAt,
+	}
+	if a.Diff != "" {
+		response.Diff = json.RawMessage(a.Diff)
+	}
+	if a.Actor != nil {
+		response.Actor = a.Actor.Name + " (" + a.Actor.Username + ")"
+	}
+	return response
+}
+
+// NewAuditLog builds an audit log entry of a change to an entity, keeping only the fields that changed between
+// before and after
+func NewAuditLog(action, entity string, entityID uint, before, after interface{}, actorID *uint, clientIP string) (*AuditLog, error) {
+	entry := &AuditLog{
+		ActorID:  actorID,
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		ClientIP: clientIP,
+	}
+
+	diff, err := AuditDiff(before, after)
+	if err != nil {
+		return nil, err
+	}
+	if len(diff) > 0 {
+		encoded, err := json.Marshal(diff)
+		if err != nil {
+			return nil, err
+		}
+		entry.Diff = string(encoded)
+	}
+	return entry, nil
+}
+
+// RecordAuditLog saves an audit log entry. It must be called inside the transaction that made the change, so the log
+// never misses or invents a change.
+func RecordAuditLog(tx *gorm.DB, entry *AuditLog) error {
+	return tx.Omit(clause.Associations).Create(entry).Error
+}
+
+// AuditDiff compares the JSON representation of an entity before and after a change and returns the changed fields.
+// before is nil for a created entity and after is nil for a deleted one. Fields hidden from JSON, such as passwords,
+// never show up. Lists are left out: they are associations, audited with their own actions.
+func AuditDiff(before, after interface{}) (map[string]AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]AuditChange)
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		for field := range fields {
+			if _, done := diff[field]; done || auditIgnoredFields[field] {
+				continue
+			}
+			from, to := beforeFields[field], afterFields[field]
+			if isAuditList(from) || isAuditList(to) || reflect.DeepEqual(from, to) {
+				continue
+			}
+			diff[field] = AuditChange{From: from, To: to}
+		}
+	}
+	return diff, nil
+}
+
+func auditFields(value interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if value == nil {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func isAuditList(value interface{}) bool {
+	_, ok := value.([]interface{})
+	return ok
+}
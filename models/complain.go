@@ -4,6 +4,14 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Fee charge review statuses of a complained operator
+const (
+	FeeChargeStatusPending  = "pending"
+	FeeChargeStatusApproved = "approved"
+	FeeChargeStatusWaived   = "waived"
 )
 
 type Complain struct {
@@ -18,6 +26,10 @@ type Complain struct {
 	Solution     string         `gorm:"default:null" json:"solution"`
 	TotalFee     uint           `gorm:"default:null" json:"total_fee"`
 	Checked      bool           `gorm:"default:false" json:"checked"`
+	CheckerID    *uint          `gorm:"default:null" json:"checker_id"`
+	CheckedAt    *time.Time     `gorm:"default:null" json:"checked_at"`
+	SolverID     *uint          `gorm:"default:null" json:"solver_id"`
+	SolvedAt     *time.Time     `gorm:"default:null" json:"solved_at"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -50,6 +62,10 @@ type ComplainUserDetail struct {
 	ComplainID           uint           `gorm:"not null" json:"complain_id"`
 	ComplainedOperatorID uint           `gorm:"not null" json:"complained_operator_id"`
 	FeeCharge            uint           `json:"fee_charge" example:"5000"`
+	FeeStatus            string         `gorm:"not null;default:pending;index" json:"fee_status"`
+	ReviewerID           *uint          `gorm:"default:null" json:"reviewer_id"`
+	ReviewedAt           *time.Time     `gorm:"default:null" json:"reviewed_at"`
+	WaiveReason          string         `gorm:"default:null" json:"waive_reason"`
 	CreatedAt            time.Time      `json:"created_at"`
 	UpdatedAt            time.Time      `json:"updated_at"`
 	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
@@ -75,6 +91,10 @@ type ComplainUserDetailResponse struct {
 	ComplainID           uint         `json:"complain_id"`
 	ComplainedOperatorID uint         `json:"complained_operator_id"`
 	FeeCharge            uint         `json:"fee_charge"`
+	FeeStatus            string       `json:"fee_status"`
+	ReviewerID           *uint        `json:"reviewer_id"`
+	ReviewedAt           *time.Time   `json:"reviewed_at"`
+	WaiveReason          string       `json:"waive_reason"`
 	CreatedAt            time.Time    `json:"created_at"`
 	UpdatedAt            time.Time    `json:"updated_at"`
 	ComplainedOperator   UserResponse `json:"complained_operator"`
@@ -92,6 +112,11 @@ type ComplainResponse struct {
 	Solution       string                          `json:"solution"`
 	TotalFee       uint                            `json:"total_fee"`
 	Checked        bool                            `json:"checked"`
+	CheckerID      *uint                           `json:"checker_id"`
+	CheckedAt      *time.Time                      `json:"checked_at"`
+	SolverID       *uint                           `json:"solver_id"`
+	SolvedAt       *time.Time                      `json:"solved_at"`
+	Solved         bool                            `json:"solved"`
 	CreatedAt      time.Time                       `json:"created_at"`
 	UpdatedAt      time.Time                       `json:"updated_at"`
 	ProductDetails []ComplainProductDetailResponse `json:"product_details"`
@@ -134,6 +159,10 @@ func (c *Complain) ToComplainResponse() ComplainResponse {
 			ComplainID:           ud.ComplainID,
 			ComplainedOperatorID: ud.ComplainedOperatorID,
 			FeeCharge:            ud.FeeCharge,
+			FeeStatus:            ud.FeeStatus,
+			ReviewerID:           ud.ReviewerID,
+			ReviewedAt:           ud.ReviewedAt,
+			WaiveReason:          ud.WaiveReason,
 			CreatedAt:            ud.CreatedAt,
 			UpdatedAt:            ud.UpdatedAt,
 		}
@@ -158,6 +187,11 @@ func (c *Complain) ToComplainResponse() ComplainResponse {
 		Solution:       c.Solution,
 		TotalFee:       c.TotalFee,
 		Checked:        c.Checked,
+		CheckerID:      c.CheckerID,
+		CheckedAt:      c.CheckedAt,
+		SolverID:       c.SolverID,
+		SolvedAt:       c.SolvedAt,
+		Solved:         c.SolvedAt != nil,
 		CreatedAt:      c.CreatedAt,
 		UpdatedAt:      c.UpdatedAt,
 		ProductDetails: productDetailResponses,
@@ -200,3 +234,42 @@ func ToComplainResponses(complains []Complain) []ComplainResponse {
 
 	return responses
 }
+
+// SyncComplainedFlags marks the order and its station records of a tracking number as complained
+// while the tracking has at least one unsolved complain, and unmarks them otherwise. Orders whose
+// flag changes get a complained changed event by the actor with the note.
+func SyncComplainedFlags(tx *gorm.DB, tracking string, actorID *uint, note string) error {
+	if tracking == "" {
+		return nil
+	}
+
+	var openCount int64
+	if err := tx.Model(&Complain{}).Where("tracking = ? AND solved_at IS NULL", tracking).Count(&openCount).Error; err != nil {
+		return err
+	}
+	complained := openCount > 0
+
+	var orders []Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tracking = ? AND complained <> ?", tracking, complained).
+		Find(&orders).Error; err != nil {
+		return err
+	}
+	events := make([]*OrderEvent, 0, len(orders))
+	for _, order := range orders {
+		event, err := NewOrderEvent(order.ID, OrderEventComplainedChanged, OrderStationAdmin,
+			map[string]bool{"complained": order.Complained}, map[string]bool{"complained": complained}, actorID, note)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	for _, model := range []interface{}{&Order{}, &Outbound{}, &QcOnline{}, &QcRibbon{}} {
+		if err := tx.Model(model).Where("tracking = ?", tracking).Update("complained", complained).Error; err != nil {
+			return err
+		}
+	}
+
+	return RecordOrderEvents(tx, events...)
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// DataMigration marks a one-time data migration as applied, so it never runs twice whatever the state of the schema
+type DataMigration struct {
+	Name      string    `gorm:"primaryKey" json:"name"`
+	AppliedAt time.Time `gorm:"not null" json:"applied_at"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -39,3 +40,33 @@ func (e *Expedition) ToExpeditionResponse() ExpeditionResponse {
 		Updated: e.UpdatedAt,
 	}
 }
+
+// MatchExpeditionByTracking finds the expedition whose code is the longest prefix of the tracking number.
+// Returns nil when no expedition code matches.
+func MatchExpeditionByTracking(db *gorm.DB, tracking string) (*Expedition, error) {
+	var expeditions []Expedition
+	if err := db.Find(&expeditions).Error; err != nil {
+		return nil, err
+	}
+
+	return matchExpeditionPrefix(expeditions, tracking), nil
+}
+
+// matchExpeditionPrefix returns the expedition whose code is the longest prefix of the tracking number, ignoring
+// case and surrounding spaces, or nil when no code matches
+func matchExpeditionPrefix(expeditions []Expedition, tracking string) *Expedition {
+	tracking = strings.ToUpper(strings.TrimSpace(tracking))
+
+	var match *Expedition
+	for i := range expeditions {
+		code := strings.ToUpper(strings.TrimSpace(expeditions[i].Code))
+		if code == "" || !strings.HasPrefix(tracking, code) {
+			continue
+		}
+		if match == nil || len(code) > len(strings.TrimSpace(match.Code)) {
+			match = &expeditions[i]
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,52 @@
+package models
+
+import "testing"
+
+func TestMatchExpeditionPrefix(t *testing.T) {
+	expeditions := []Expedition{
+		{ID: 1, Code: "JN", Name: "JNE"},
+		{ID: 2, Code: "JNT", Name: "J&T Express"},
+		{ID: 3, Code: "SPX", Name: "Shopee Express"},
+		{ID: 4, Code: " jx ", Name: "J&T Cargo"},
+		{ID: 5, Code: "", Name: "Unknown"},
+		{ID: 6, Code: "JNTC", Name: "J&T Cargo Priority"},
+	}
+
+	tests := []struct {
+		tracking string
+		want     uint
+	}{
+		{tracking: "JN1234567890", want: 1},
+		// The longest matching code wins, whatever the order of the expeditions
+		{tracking: "JNT1234567890", want: 2},
+		{tracking: "JNTC1234567890", want: 6},
+		{tracking: "spx0012345", want: 3},
+		{tracking: "  SPX0012345  ", want: 3},
+		{tracking: "JX001", want: 4},
+		{tracking: "TKP001", want: 0},
+		{tracking: "J", want: 0},
+		{tracking: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		match := matchExpeditionPrefix(expeditions, tt.tracking)
+		switch {
+		case tt.want == 0 && match != nil:
+			t.Errorf("'%s': expected no match, got %s", tt.tracking, match.Code)
+		case tt.want != 0 && match == nil:
+			t.Errorf("'%s': expected expedition %d, got no match", tt.tracking, tt.want)
+		case tt.want != 0 && match.ID != tt.want:
+			t.Errorf("'%s': expected expedition %d, got %d (%s)", tt.tracking, tt.want, match.ID, match.Code)
+		}
+	}
+
+	// The longest prefix wins when it comes first too
+	reversed := []Expedition{{ID: 6, Code: "JNTC"}, {ID: 2, Code: "JNT"}, {ID: 1, Code: "JN"}}
+	if match := matchExpeditionPrefix(reversed, "JNTC99"); match == nil || match.ID != 6 {
+		t.Errorf("expected expedition 6, got %+v", match)
+	}
+
+	if match := matchExpeditionPrefix(nil, "JNT001"); match != nil {
+		t.Errorf("expected no match without expeditions, got %+v", match)
+	}
+}
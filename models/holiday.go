@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Holiday is a day the warehouse does not work. Holidays do not count as working time in SLA calculations.
+type Holiday struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Date      time.Time `gorm:"type:date;not null;unique" json:"date"`
+	Name      string    `gorm:"not null" json:"name"`
+	CreatorID uint      `gorm:"not null" json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Associations
+	Creator *User `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+}
+
+type HolidayResponse struct {
+	ID      uint      `json:"id"`
+	Date    string    `json:"date"`
+	Name    string    `json:"name"`
+	Creator string    `json:"creator"`
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// DateString returns the holiday date in YYYY-MM-DD format. Dates are read back from the database as midnight UTC,
+// so they are formatted without converting them to another timezone.
+func (h *Holiday) DateString() string {
+	return h.Date.Format("2006-01-02")
+}
+
+// ToHolidayResponse converts Holiday model to HolidayResponse
+func (h *Holiday) ToHolidayResponse() HolidayResponse {
+	response := HolidayResponse{
+		ID:      h.ID,
+		Date:    h.DateString(),
+		Name:    h.Name,
+		Created: h.CreatedAt,
+		Updated: h.UpdatedAt,
+	}
+	if h.Creator != nil {
+		response.Creator = h.Creator.Username
+	}
+	return response
+}
+
+// LoadHolidayDates returns the dates of all holidays in YYYY-MM-DD format
+func LoadHolidayDates(db *gorm.DB) ([]string, error) {
+	var holidays []Holiday
+	if err := db.Select("date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+
+	dates := make([]string, len(holidays))
+	for i, holiday := range holidays {
+		dates[i] = holiday.DateString()
+	}
+	return dates, nil
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job statuses
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job types
+const (
+	JobTypeBulkCreateOrders  = "bulk_create_orders"
+	JobTypeImportGineeOrders = "import_ginee_orders"
+	JobTypeExportFeeCharges  = "export_fee_charges"
+	JobTypeExportStockOpname = "export_stock_opname"
+	JobTypeExportManifest    = "export_manifest"
+	JobTypeExportPickList    = "export_pick_list"
+	JobTypeImportProducts    = "import_products"
+)
+
+// DefaultJobMaxAttempts is the number of times a failing job is run before it is marked as failed
+const DefaultJobMaxAttempts = 3
+
+// Job is a long-running task run in the background by the job runner. Jobs are queued in this table and claimed by
+// the runner, so they survive restarts and need no external broker. A job may produce a JSON result and a file.
+type Job struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Type            string     `gorm:"not null;index" json:"type"`
+	Status          string     `gorm:"not null;index" json:"status"`
+	Params          string     `gorm:"type:text;not null" json:"-"`
+	Progress        int        `gorm:"not null;default:0" json:"progress"`
+	Total           int        `gorm:"not null;default:0" json:"total"`
+	Attempts        int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts     int        `gorm:"not null;default:3" json:"max_attempts"`
+	Error           string     `gorm:"type:text;default:null" json:"error"`
+	Result          string     `gorm:"type:text;default:null" json:"-"`
+	FileName        string     `gorm:"default:null" json:"file_name"`
+	ContentType     string     `gorm:"default:null" json:"content_type"`
+	File            []byte     `gorm:"type:bytea;default:null" json:"-"`
+	CancelRequested bool       `gorm:"not null;default:false" json:"cancel_requested"`
+	RunAt           time.Time  `gorm:"not null;index" json:"run_at"`
+	HeartbeatAt     *time.Time `gorm:"default:null" json:"heartbeat_at"`
+	StartedAt       *time.Time `gorm:"default:null" json:"started_at"`
+	FinishedAt      *time.Time `gorm:"default:null" json:"finished_at"`
+	CreatorID       uint       `gorm:"not null;index" json:"creator_id"`
+	CreatedAt       time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Associations
+	Creator *User `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+}
+
+type JobResponse struct {
+	ID              uint            `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Progress        int             `json:"progress"`
+	Total           int             `json:"total"`
+	Percent         int             `json:"percent"`
+	Attempts        int             `json:"attempts"`
+	MaxAttempts     int             `json:"max_attempts"`
+	Error           string          `json:"error"`
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	FileName        string          `json:"file_name"`
+	HasFile         bool            `json:"has_file"`
+	CancelRequested bool            `json:"cancel_requested"`
+	RunAt           time.Time       `json:"run_at"`
+	StartedAt       *time.Time      `json:"started_at"`
+	FinishedAt      *time.Time      `json:"finished_at"`
+	CreatorID       uint            `json:"creator_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Creator         *UserResponse   `json:"creator,omitempty"`
+}
+
+// NewJob builds a queued job with JSON encoded params, to be run as soon as a worker is free
+func NewJob(jobType string, params interface{}, creatorID uint) (*Job, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		Type:        jobType,
+		Status:      JobStatusQueued,
+		Params:      string(encoded),
+		MaxAttempts: DefaultJobMaxAttempts,
+		RunAt:       time.Now(),
+		CreatorID:   creatorID,
+	}, nil
+}
+
+// IsFinished checks whether the job will not run again
+func (j *Job) IsFinished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
+
+// ToJobResponse converts Job model to JobResponse. The file is not included; it is downloaded separately.
+func (j *Job) ToJobResponse() JobResponse {
+	response := JobResponse{
+		ID:              j.ID,
+		Type:            j.Type,
+		Status:          j.Status,
+		Progress:        j.Progress,
+		Total:           j.Total,
+		Attempts:        j.Attempts,
+		MaxAttempts:     j.MaxAttempts,
+		Error:           j.Error,
+		FileName:        j.FileName,
+		HasFile:         j.FileName != "",
+		CancelRequested: j.CancelRequested,
+		RunAt:           j.RunAt,
+		StartedAt:       j.StartedAt,
+		FinishedAt:      j.FinishedAt,
+		CreatorID:       j.CreatorID,
+		CreatedAt:       j.CreatedAt,
+		UpdatedAt:       j.UpdatedAt,
+	}
+
+	if j.Total > 0 {
+		response.Percent = j.Progress * 100 / j.Total
+	}
+	if j.Status == JobStatusSucceeded {
+		response.Percent = 100
+	}
+
+	if j.Result != "" && json.Valid([]byte(j.Result)) {
+		response.Result = json.RawMessage(j.Result)
+	}
+
+	// Include creator data if loaded
+	if j.Creator != nil {
+		creatorResp := j.Creator.ToUserResponse()
+		response.Creator = &creatorResp
+	}
+
+	return response
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Manifest statuses
+const (
+	ManifestStatusOpen   = "open"
+	ManifestStatusClosed = "closed"
+)
+
+// Manifest is the handover document of the outbound parcels of one expedition picked up by a courier driver.
+// While open it covers the unmanifested outbound scans of its expedition since WindowStart; closing it
+// attaches those parcels and ends the window.
+type Manifest struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Code            string         `gorm:"unique;not null" json:"code"`
+	ExpeditionSlug  string         `gorm:"not null;index" json:"expedition_slug"`
+	Expedition      string         `gorm:"not null" json:"expedition"`
+	ExpeditionColor string         `json:"expedition_color"`
+	Status          string         `gorm:"not null;index" json:"status"`
+	WindowStart     time.Time      `gorm:"not null" json:"window_start"`
+	WindowEnd       *time.Time     `gorm:"default:null" json:"window_end"`
+	DriverName      string         `gorm:"default:null" json:"driver_name"`
+	Notes           string         `gorm:"default:null" json:"notes"`
+	OpenerID        uint           `gorm:"not null" json:"opener_id"`
+	CloserID        *uint          `gorm:"default:null" json:"closer_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Associations
+	Opener    *User      `gorm:"foreignKey:OpenerID" json:"opener,omitempty"`
+	Closer    *User      `gorm:"foreignKey:CloserID" json:"closer,omitempty"`
+	Outbounds []Outbound `gorm:"foreignKey:ManifestID" json:"outbounds"`
+}
+
+// ManifestOperatorCount is the number of manifest parcels scanned by one outbound operator
+type ManifestOperatorCount struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Parcels  int    `json:"parcels"`
+}
+
+type ManifestResponse struct {
+	ID              uint                    `json:"id"`
+	Code            string                  `json:"code"`
+	ExpeditionSlug  string                  `json:"expedition_slug"`
+	Expedition      string                  `json:"expedition"`
+	ExpeditionColor string                  `json:"expedition_color"`
+	Status          string                  `json:"status"`
+	WindowStart     time.Time               `json:"window_start"`
+	WindowEnd       *time.Time              `json:"window_end"`
+	DriverName      string                  `json:"driver_name"`
+	Notes           string                  `json:"notes"`
+	OpenerID        uint                    `json:"opener_id"`
+	CloserID        *uint                   `json:"closer_id"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	TotalParcels    int                     `json:"total_parcels"`
+	Operators       []ManifestOperatorCount `json:"operators"`
+	Opener          *UserResponse           `json:"opener,omitempty"`
+	Closer          *UserResponse           `json:"closer,omitempty"`
+	Outbounds       []OutboundResponse      `json:"outbounds"`
+}
+
+// ToManifestResponse converts Manifest model to ManifestResponse
+func (m *Manifest) ToManifestResponse() ManifestResponse {
+	response := ManifestResponse{
+		ID:              m.ID,
+		Code:            m.Code,
+		ExpeditionSlug:  m.ExpeditionSlug,
+		Expedition:      m.Expedition,
+		ExpeditionColor: m.ExpeditionColor,
+		Status:          m.Status,
+		WindowStart:     m.WindowStart,
+		WindowEnd:       m.WindowEnd,
+		DriverName:      m.DriverName,
+		Notes:           m.Notes,
+		OpenerID:        m.OpenerID,
+		CloserID:        m.CloserID,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+		TotalParcels:    len(m.Outbounds),
+		Operators:       m.OperatorCounts(),
+		Outbounds:       ToOutboundResponses(m.Outbounds),
+	}
+
+	// Include opener data if loaded
+	if m.Opener != nil {
+		openerResp := m.Opener.ToUserResponse()
+		response.Opener = &openerResp
+	}
+
+	// Include closer data if loaded
+	if m.Closer != nil {
+		closerResp := m.Closer.ToUserResponse()
+		response.Closer = &closerResp
+	}
+
+	return response
+}
+
+// OperatorCounts counts the manifest parcels per operator who scanned them, most parcels first.
+// Outbounds should be loaded with their User.
+func (m *Manifest) OperatorCounts() []ManifestOperatorCount {
+	counts := []ManifestOperatorCount{}
+	index := make(map[uint]int)
+
+	for _, ob := range m.Outbounds {
+		if i, exists := index[ob.UserID]; exists {
+			counts[i].Parcels++
+			continue
+		}
+
+		count := ManifestOperatorCount{UserID: ob.UserID, Parcels: 1}
+		if ob.User != nil {
+			count.Username = ob.User.Username
+			count.Name = ob.User.Name
+		}
+		index[ob.UserID] = len(counts)
+		counts = append(counts, count)
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].Parcels > counts[j].Parcels
+	})
+
+	return counts
+}
+
+// PendingOutbounds returns the query of outbound scans covered by an open manifest:
+// scans of its expedition since the window start that are not attached to any manifest yet.
+func (m *Manifest) PendingOutbounds(db *gorm.DB) *gorm.DB {
+	return db.Model(&Outbound{}).
+		Where("expedition_slug = ? AND manifest_id IS NULL AND created_at >= ?", m.ExpeditionSlug, m.WindowStart)
+}
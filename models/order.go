@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"livo-backend-2.0/utils"
+
 	"gorm.io/gorm"
 )
 
@@ -27,6 +29,9 @@ type Order struct {
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	CancelAt        *time.Time     `gorm:"default:null" json:"cancel_at"`
+	CancelReason    string         `gorm:"default:null" json:"cancel_reason"`
+	SlaFlag         string         `gorm:"default:null;index" json:"sla_flag"`
+	SlaFlaggedAt    *time.Time     `gorm:"default:null" json:"sla_flagged_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Associations
@@ -37,79 +42,85 @@ type Order struct {
 	Canceler     *User         `gorm:"foreignKey:CancelerID" json:"canceler,omitempty"`
 }
 
+// OrderDetail is an ordered SKU. A bundle SKU is kept as a bundle line (IsBundle) that is not picked itself;
+// its components are stored as separate details of the same order pointing to it with BundleDetailID.
 type OrderDetail struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	OrderID     uint           `json:"order_id"`
-	Sku         string         `json:"sku" gorm:"index"`
-	ProductName string         `json:"product_name"`
-	Variant     string         `json:"variant"`
-	Quantity    int            `json:"quantity"`
-	Product     *Product       `json:"product,omitempty" gorm:"-"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	OrderID        uint           `json:"order_id"`
+	Sku            string         `json:"sku" gorm:"index"`
+	ProductName    string         `json:"product_name"`
+	Variant        string         `json:"variant"`
+	Quantity       int            `json:"quantity"`
+	IsBundle       bool           `json:"is_bundle" gorm:"default:false"`
+	BundleDetailID *uint          `json:"bundle_detail_id" gorm:"default:null;index"`
+	Product        *Product       `json:"product,omitempty" gorm:"-"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // OrderResponse represents order data for API responses
 type OrderResponse struct {
-	ID           uint                  `json:"id"`
-	OrderGineeID string                `json:"order_ginee_id"`
-	Status       string                `json:"status"`
-	Channel      string                `json:"channel"`
-	Store        string                `json:"store"`
-	Buyer        string                `json:"buyer"`
-	Courier      string                `json:"courier"`
-	Tracking     string                `json:"tracking"`
-	Complained   bool                  `json:"complained"`
-	ImportedBy   string                `json:"imported_by"`
-	UpdatedBy    string                `json:"updated_by"`
-	PickedBy     string                `json:"picked_by"`
-	CanceledBy   string                `json:"canceled_by"`
-	PickedAt     string                `json:"picked_at"`
-	CreatedAt    time.Time             `json:"created_at"`
-	UpdatedAt    time.Time             `json:"updated_at"`
-	CancelAt     string                `json:"cancel_at"`
-	OrderDetails []OrderDetailResponse `json:"order_details"`
+	ID              uint                  `json:"id"`
+	OrderGineeID    string                `json:"order_ginee_id"`
+	Status          string                `json:"status"`
+	Channel         string                `json:"channel"`
+	Store           string                `json:"store"`
+	Buyer           string                `json:"buyer"`
+	Courier         string                `json:"courier"`
+	Tracking        string                `json:"tracking"`
+	Complained      bool                  `json:"complained"`
+	ProcessingLimit time.Time             `json:"processing_limit"`
+	SlaFlag         string                `json:"sla_flag"`
+	ImportedBy      string                `json:"imported_by"`
+	UpdatedBy       string                `json:"updated_by"`
+	PickedBy        string                `json:"picked_by"`
+	CanceledBy      string                `json:"canceled_by"`
+	PickedAt        string                `json:"picked_at"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	CancelAt        string                `json:"cancel_at"`
+	CancelReason    string                `json:"cancel_reason"`
+	OrderDetails    []OrderDetailResponse `json:"order_details"`
 }
 
 type OrderDetailResponse struct {
-	ID          uint             `json:"id"`
-	Sku         string           `json:"sku"`
-	ProductName string           `json:"product_name"`
-	Variant     string           `json:"variant"`
-	Quantity    int              `json:"quantity"`
-	Product     *ProductResponse `json:"product,omitempty"`
+	ID          uint                  `json:"id"`
+	Sku         string                `json:"sku"`
+	ProductName string                `json:"product_name"`
+	Variant     string                `json:"variant"`
+	Quantity    int                   `json:"quantity"`
+	IsBundle    bool                  `json:"is_bundle"`
+	Product     *ProductResponse      `json:"product,omitempty"`
+	Components  []OrderDetailResponse `json:"components,omitempty"`
 }
 
 // ToOrderResponse converts Order model to OrderResponse
 func (o *Order) ToOrderResponse() OrderResponse {
-	details := make([]OrderDetailResponse, len(o.OrderDetails))
-	for i, detail := range o.OrderDetails {
-		detailResp := OrderDetailResponse{
-			ID:          detail.ID,
-			Sku:         detail.Sku,
-			ProductName: detail.ProductName,
-			Variant:     detail.Variant,
-			Quantity:    detail.Quantity,
+	details := make([]OrderDetailResponse, 0, len(o.OrderDetails))
+	for _, detail := range o.OrderDetails {
+		if detail.BundleDetailID != nil && o.hasOrderDetail(*detail.BundleDetailID) {
+			continue
 		}
 
-		// Include product data if exists
-		if detail.Product != nil {
-			detailResp.Product = &ProductResponse{
-				ID:    detail.Product.ID,
-				Sku:   detail.Product.Sku,
-				Name:  detail.Product.Name,
-				Image: detail.Product.Image,
+		detailResp := detail.ToOrderDetailResponse()
+
+		// Bundle lines show the components that are picked for them
+		if detail.IsBundle {
+			for _, component := range o.OrderDetails {
+				if component.BundleDetailID != nil && *component.BundleDetailID == detail.ID {
+					detailResp.Components = append(detailResp.Components, component.ToOrderDetailResponse())
+				}
 			}
 		}
 
-		details[i] = detailResp
+		details = append(details, detailResp)
 	}
 
 	// Handle picked_at field
 	var pickedAtStr string
 	if o.PickedAt != nil {
-		pickedAtStr = o.PickedAt.Format("2006-01-02 15:04:05")
+		pickedAtStr = o.PickedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
 	} else {
 		pickedAtStr = "Not picked yet"
 	}
@@ -117,7 +128,7 @@ func (o *Order) ToOrderResponse() OrderResponse {
 	// Handle canceled_at field
 	var canceledAtStr string
 	if o.CancelAt != nil {
-		canceledAtStr = o.CancelAt.Format("2006-01-02 15:04:05")
+		canceledAtStr = o.CancelAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05")
 	} else {
 		canceledAtStr = "Not canceled"
 	}
@@ -155,23 +166,60 @@ func (o *Order) ToOrderResponse() OrderResponse {
 	}
 
 	return OrderResponse{
-		ID:           o.ID,
-		OrderGineeID: o.OrderGineeID,
-		Status:       o.Status,
-		Channel:      o.Channel,
-		Store:        o.Store,
-		Buyer:        o.Buyer,
-		Courier:      o.Courier,
-		Tracking:     o.Tracking,
-		Complained:   o.Complained,
-		ImportedBy:   importedByStr,
-		UpdatedBy:    updatedByStr,
-		PickedBy:     pickedByStr,
-		CanceledBy:   canceledByStr,
-		PickedAt:     pickedAtStr,
-		CreatedAt:    o.CreatedAt,
-		UpdatedAt:    o.UpdatedAt,
-		CancelAt:     canceledAtStr,
-		OrderDetails: details,
+		ID:              o.ID,
+		OrderGineeID:    o.OrderGineeID,
+		Status:          o.Status,
+		Channel:         o.Channel,
+		Store:           o.Store,
+		Buyer:           o.Buyer,
+		Courier:         o.Courier,
+		Tracking:        o.Tracking,
+		Complained:      o.Complained,
+		ProcessingLimit: o.ProcessingLimit,
+		SlaFlag:         o.SlaFlag,
+		ImportedBy:      importedByStr,
+		UpdatedBy:       updatedByStr,
+		PickedBy:        pickedByStr,
+		CanceledBy:      canceledByStr,
+		PickedAt:        pickedAtStr,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+		CancelAt:        canceledAtStr,
+		CancelReason:    o.CancelReason,
+		OrderDetails:    details,
+	}
+}
+
+// ToOrderDetailResponse converts OrderDetail model to OrderDetailResponse without components
+func (od *OrderDetail) ToOrderDetailResponse() OrderDetailResponse {
+	response := OrderDetailResponse{
+		ID:          od.ID,
+		Sku:         od.Sku,
+		ProductName: od.ProductName,
+		Variant:     od.Variant,
+		Quantity:    od.Quantity,
+		IsBundle:    od.IsBundle,
+	}
+
+	// Include product data if exists
+	if od.Product != nil {
+		response.Product = &ProductResponse{
+			ID:    od.Product.ID,
+			Sku:   od.Product.Sku,
+			Name:  od.Product.Name,
+			Image: od.Product.Image,
+		}
+	}
+
+	return response
+}
+
+// hasOrderDetail checks if the detail is loaded in the order details
+func (o *Order) hasOrderDetail(detailID uint) bool {
+	for _, detail := range o.OrderDetails {
+		if detail.ID == detailID {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Order event types
+const (
+	OrderEventCreated           = "created"
+	OrderEventUpdated           = "updated"
+	OrderEventStatusChanged     = "status_changed"
+	OrderEventDetailAdded       = "detail_added"
+	OrderEventDetailUpdated     = "detail_updated"
+	OrderEventDetailRemoved     = "detail_removed"
+	OrderEventComplainedChanged = "complained_changed"
+)
+
+// Stations where order events happen
+const (
+	OrderStationImport   = "import"
+	OrderStationWebhook  = "webhook"
+	OrderStationAdmin    = "admin"
+	OrderStationPick     = "pick"
+	OrderStationQC       = "qc"
+	OrderStationOutbound = "outbound"
+	OrderStationManifest = "manifest"
+	OrderStationReturn   = "return"
+)
+
+// orderStatusStations is the station an order is at when it moves to a status
+var orderStatusStations = map[string]string{
+	OrderStatusReadyToPick: OrderStationPick,
+	OrderStatusPicking:     OrderStationPick,
+	OrderStatusPicked:      OrderStationPick,
+	OrderStatusQC:          OrderStationQC,
+	OrderStatusPacked:      OrderStationQC,
+	OrderStatusOutbound:    OrderStationOutbound,
+	OrderStatusShipped:     OrderStationManifest,
+	OrderStatusCancelled:   OrderStationAdmin,
+	OrderStatusReturned:    OrderStationReturn,
+}
+
+// OrderEvent is an entry of the append-only history of an order: who changed what and when.
+// Events are only ever created, never updated or deleted.
+type OrderEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	OrderID    uint      `gorm:"not null;index" json:"order_id"`
+	Type       string    `gorm:"not null;index" json:"type"`
+	Station    string    `gorm:"not null" json:"station"`
+	FromStatus string    `gorm:"default:null" json:"from_status"`
+	ToStatus   string    `gorm:"default:null" json:"to_status"`
+	Before     string    `gorm:"type:text;default:null" json:"before"`
+	After      string    `gorm:"type:text;default:null" json:"after"`
+	Note       string    `gorm:"default:null" json:"note"`
+	ActorID    *uint     `gorm:"default:null;index" json:"actor_id"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+
+	// Associations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+type OrderEventResponse struct {
+	ID         uint            `json:"id"`
+	Type       string          `json:"type"`
+	Station    string          `json:"station"`
+	FromStatus string          `json:"from_status,omitempty"`
+	ToStatus   string          `json:"to_status,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Note       string          `json:"note,omitempty"`
+	ActorID    *uint           `json:"actor_id"`
+	Actor      string          `json:"actor"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ToOrderEventResponse converts OrderEvent model to OrderEventResponse
+func (e *OrderEvent) ToOrderEventResponse() OrderEventResponse {
+	response := OrderEventResponse{
+		ID:         e.ID,
+		Type:       e.Type,
+		Station:    e.Station,
+		FromStatus: e.FromStatus,
+		ToStatus:   e.ToStatus,
+		Note:       e.Note,
+		ActorID:    e.ActorID,
+		Actor:      "System",
+		CreatedAt:  e.CreatedAt,
+	}
+	if e.Before != "" {
+		response.Before = json.RawMessage(e.Before)
+	}
+	if e.After != "" {
+		response.After = json.RawMessage(e.After)
+	}
+	if e.Actor != nil {
+		response.Actor = e.Actor.Name + " (" + e.Actor.Username + ")"
+	}
+	return response
+}
+
+// NewOrderEvent builds an event of the order with the values before and after the change, encoded as JSON.
+// A nil before or after value is left empty.
+func NewOrderEvent(orderID uint, eventType, station string, before, after interface{}, actorID *uint, note string) (*OrderEvent, error) {
+	event := &OrderEvent{
+		OrderID: orderID,
+		Type:    eventType,
+		Station: station,
+		Note:    note,
+		ActorID: actorID,
+	}
+
+	var err error
+	if event.Before, err = encodeOrderEventValue(before); err != nil {
+		return nil, err
+	}
+	if event.After, err = encodeOrderEventValue(after); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// NewOrderStatusEvent builds the event of a status change of the order, once it moved from fromStatus with
+// TransitionTo. The station is the one of the new status.
+func NewOrderStatusEvent(order *Order, fromStatus string, actorID *uint, note string) *OrderEvent {
+	return &OrderEvent{
+		OrderID:    order.ID,
+		Type:       OrderEventStatusChanged,
+		Station:    orderStatusStations[order.Status],
+		FromStatus: fromStatus,
+		ToStatus:   order.Status,
+		Note:       note,
+		ActorID:    actorID,
+	}
+}
+
+// NewOrderCreatedEvent builds the event of the creation of the order, with the order as created
+func NewOrderCreatedEvent(order *Order, station, note string) (*OrderEvent, error) {
+	event, err := NewOrderEvent(order.ID, OrderEventCreated, station, nil, order.eventSnapshot(), order.ImporterID, note)
+	if err != nil {
+		return nil, err
+	}
+	event.ToStatus = order.Status
+	return event, nil
+}
+
+// NewOrderDetailEvent builds the event of an added, updated or removed order detail. before is nil for an added
+// detail, after is nil for a removed one.
+func NewOrderDetailEvent(orderID uint, eventType string, before, after *OrderDetail, actorID *uint) (*OrderEvent, error) {
+	var beforeValue, afterValue interface{}
+	if before != nil {
+		beforeValue = before.eventSnapshot()
+	}
+	if after != nil {
+		afterValue = after.eventSnapshot()
+	}
+	return NewOrderEvent(orderID, eventType, OrderStationAdmin, beforeValue, afterValue, actorID, "")
+}
+
+// RecordOrderEvents appends events to the order history. It must be called inside the transaction that made the
+// changes, so the history never misses or invents a change.
+func RecordOrderEvents(tx *gorm.DB, events ...*OrderEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(events).Error
+}
+
+func encodeOrderEventValue(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// orderSnapshot is the state of an order kept in its created event
+type orderSnapshot struct {
+	OrderGineeID    string                `json:"order_ginee_id"`
+	Status          string                `json:"status"`
+	Channel         string                `json:"channel"`
+	Store           string                `json:"store"`
+	Buyer           string                `json:"buyer"`
+	Address         string                `json:"address"`
+	Courier         string                `json:"courier"`
+	Tracking        string                `json:"tracking"`
+	ProcessingLimit time.Time             `json:"processing_limit"`
+	OrderDetails    []orderDetailSnapshot `json:"order_details"`
+}
+
+// orderDetailSnapshot is the state of an order detail kept in order events
+type orderDetailSnapshot struct {
+	ID          uint   `json:"id"`
+	Sku         string `json:"sku"`
+	ProductName string `json:"product_name"`
+	Variant     string `json:"variant"`
+	Quantity    int    `json:"quantity"`
+	IsBundle    bool   `json:"is_bundle"`
+}
+
+func (o *Order) eventSnapshot() orderSnapshot {
+	snapshot := orderSnapshot{
+		OrderGineeID:    o.OrderGineeID,
+		Status:          o.Status,
+		Channel:         o.Channel,
+		Store:           o.Store,
+		Buyer:           o.Buyer,
+		Address:         o.Address,
+		Courier:         o.Courier,
+		Tracking:        o.Tracking,
+		ProcessingLimit: o.ProcessingLimit,
+		OrderDetails:    []orderDetailSnapshot{},
+	}
+	// Bundle components follow from the bundle lines, only the ordered lines are kept
+	for _, detail := range o.OrderDetails {
+		if detail.BundleDetailID == nil {
+			snapshot.OrderDetails = append(snapshot.OrderDetails, detail.eventSnapshot())
+		}
+	}
+	return snapshot
+}
+
+func (od *OrderDetail) eventSnapshot() orderDetailSnapshot {
+	return orderDetailSnapshot{
+		ID:          od.ID,
+		Sku:         od.Sku,
+		ProductName: od.ProductName,
+		Variant:     od.Variant,
+		Quantity:    od.Quantity,
+		IsBundle:    od.IsBundle,
+	}
+}
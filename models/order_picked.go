@@ -7,12 +7,13 @@ import (
 )
 
 type PickOrder struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	OrderID   uint           `gorm:"not null;index" json:"order_id"`
-	PickerID  uint           `gorm:"not null;index" json:"picker_id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	OrderID    uint           `gorm:"not null;index" json:"order_id"`
+	PickerID   uint           `gorm:"not null;index" json:"picker_id"`
+	PickWaveID *uint          `gorm:"default:null;index" json:"pick_wave_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Associations
 	Order            *Order            `gorm:"foreignKey:OrderID" json:"order,omitempty"`
@@ -36,6 +37,7 @@ type PickOrderResponse struct {
 	ID               uint                      `json:"id"`
 	OrderID          uint                      `json:"order_id"`
 	PickerID         uint                      `json:"picker_id"`
+	PickWaveID       *uint                     `json:"pick_wave_id"`
 	CreatedAt        time.Time                 `json:"created_at"`
 	UpdatedAt        time.Time                 `json:"updated_at"`
 	Order            *OrderResponse            `json:"order,omitempty"`
@@ -83,6 +85,7 @@ func (po *PickOrder) ToPickOrderResponse() PickOrderResponse {
 		ID:               po.ID,
 		OrderID:          po.OrderID,
 		PickerID:         po.PickerID,
+		PickWaveID:       po.PickWaveID,
 		CreatedAt:        po.CreatedAt,
 		UpdatedAt:        po.UpdatedAt,
 		PickOrderDetails: details,
@@ -114,3 +117,119 @@ func (po *PickOrder) LoadProducts(db *gorm.DB) error {
 	}
 	return nil
 }
+
+// Pick comparison line statuses
+const (
+	PickLineComplete   = "complete"
+	PickLineShort      = "short"
+	PickLineOver       = "over"
+	PickLineUnexpected = "unexpected"
+)
+
+// PickRequirement represents the quantity of a SKU that has to be picked for an order
+type PickRequirement struct {
+	Sku         string `json:"sku"`
+	ProductName string `json:"product_name"`
+	Variant     string `json:"variant"`
+	Quantity    int    `json:"quantity"`
+}
+
+// PickComparisonLine compares the required quantity of a SKU with the picked quantity
+type PickComparisonLine struct {
+	Sku         string `json:"sku"`
+	ProductName string `json:"product_name"`
+	Variant     string `json:"variant"`
+	Required    int    `json:"required"`
+	Picked      int    `json:"picked"`
+	Status      string `json:"status"`
+}
+
+// PickRequirements returns the SKUs and quantities that have to be picked for the order,
+// merging order details that share the same SKU. Bundle lines are picked as their components.
+func (o *Order) PickRequirements() []PickRequirement {
+	var requirements []PickRequirement
+	index := make(map[string]int)
+
+	for _, detail := range o.OrderDetails {
+		if detail.IsBundle {
+			continue
+		}
+
+		if i, exists := index[detail.Sku]; exists {
+			requirements[i].Quantity += detail.Quantity
+			continue
+		}
+
+		index[detail.Sku] = len(requirements)
+		requirements = append(requirements, PickRequirement{
+			Sku:         detail.Sku,
+			ProductName: detail.ProductName,
+			Variant:     detail.Variant,
+			Quantity:    detail.Quantity,
+		})
+	}
+
+	return requirements
+}
+
+// ComparePickedItems compares picked items against the order requirements
+func ComparePickedItems(requirements []PickRequirement, details []PickOrderDetail) []PickComparisonLine {
+	picked := make(map[string]int)
+	for _, detail := range details {
+		picked[detail.Sku] += detail.Quantity
+	}
+
+	lines := make([]PickComparisonLine, 0, len(requirements))
+	required := make(map[string]bool)
+	for _, req := range requirements {
+		required[req.Sku] = true
+
+		line := PickComparisonLine{
+			Sku:         req.Sku,
+			ProductName: req.ProductName,
+			Variant:     req.Variant,
+			Required:    req.Quantity,
+			Picked:      picked[req.Sku],
+		}
+
+		switch {
+		case line.Picked == line.Required:
+			line.Status = PickLineComplete
+		case line.Picked < line.Required:
+			line.Status = PickLineShort
+		default:
+			line.Status = PickLineOver
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Items that were picked but are not part of the order
+	for _, detail := range details {
+		if required[detail.Sku] {
+			continue
+		}
+		required[detail.Sku] = true
+
+		lines = append(lines, PickComparisonLine{
+			Sku:         detail.Sku,
+			ProductName: detail.ProductName,
+			Variant:     detail.Variant,
+			Required:    0,
+			Picked:      picked[detail.Sku],
+			Status:      PickLineUnexpected,
+		})
+	}
+
+	return lines
+}
+
+// IsPickComplete checks if every comparison line is complete
+func IsPickComplete(lines []PickComparisonLine) bool {
+	for _, line := range lines {
+		if line.Status != PickLineComplete {
+			return false
+		}
+	}
+	return true
+}
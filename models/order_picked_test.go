@@ -0,0 +1,83 @@
+package models
+
+import "testing"
+
+func TestComparePickedItems(t *testing.T) {
+	requirements := []PickRequirement{
+		{Sku: "SKU-A", ProductName: "Kaos", Variant: "M", Quantity: 2},
+		{Sku: "SKU-B", ProductName: "Topi", Quantity: 1},
+		{Sku: "SKU-C", ProductName: "Tas", Quantity: 3},
+		{Sku: "SKU-D", ProductName: "Kaus Kaki", Quantity: 1},
+	}
+	details := []PickOrderDetail{
+		// Scans of the same SKU are added up
+		{Sku: "SKU-A", ProductName: "Kaos", Variant: "M", Quantity: 1},
+		{Sku: "SKU-A", ProductName: "Kaos", Variant: "M", Quantity: 1},
+		{Sku: "SKU-B", ProductName: "Topi", Quantity: 2},
+		{Sku: "SKU-C", ProductName: "Tas", Quantity: 1},
+		{Sku: "SKU-X", ProductName: "Sabuk", Quantity: 1},
+		{Sku: "SKU-X", ProductName: "Sabuk", Quantity: 2},
+	}
+
+	lines := ComparePickedItems(requirements, details)
+
+	want := []PickComparisonLine{
+		{Sku: "SKU-A", ProductName: "Kaos", Variant: "M", Required: 2, Picked: 2, Status: PickLineComplete},
+		{Sku: "SKU-B", ProductName: "Topi", Required: 1, Picked: 2, Status: PickLineOver},
+		{Sku: "SKU-C", ProductName: "Tas", Required: 3, Picked: 1, Status: PickLineShort},
+		{Sku: "SKU-D", ProductName: "Kaus Kaki", Required: 1, Picked: 0, Status: PickLineShort},
+		{Sku: "SKU-X", ProductName: "Sabuk", Required: 0, Picked: 3, Status: PickLineUnexpected},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %+v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: got %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestComparePickedItemsWithoutPicks(t *testing.T) {
+	lines := ComparePickedItems([]PickRequirement{{Sku: "SKU-A", Quantity: 1}}, nil)
+	if len(lines) != 1 || lines[0].Status != PickLineShort || lines[0].Picked != 0 {
+		t.Fatalf("expected a single short line, got %+v", lines)
+	}
+}
+
+func TestIsPickComplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []PickComparisonLine
+		want  bool
+	}{
+		{name: "all complete", lines: []PickComparisonLine{{Status: PickLineComplete}, {Status: PickLineComplete}}, want: true},
+		{name: "short", lines: []PickComparisonLine{{Status: PickLineComplete}, {Status: PickLineShort}}, want: false},
+		{name: "over", lines: []PickComparisonLine{{Status: PickLineOver}}, want: false},
+		{name: "unexpected", lines: []PickComparisonLine{{Status: PickLineComplete}, {Status: PickLineUnexpected}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPickComplete(tt.lines); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparePickedItemsCompletesBundleOrders(t *testing.T) {
+	// Bundle lines are picked as their components
+	order := Order{OrderDetails: []OrderDetail{
+		{Sku: "BUNDLE-1", Quantity: 1, IsBundle: true},
+		{Sku: "SKU-A", Quantity: 1},
+		{Sku: "SKU-A", Quantity: 1},
+		{Sku: "SKU-B", Quantity: 1},
+	}}
+	details := []PickOrderDetail{{Sku: "SKU-A", Quantity: 2}, {Sku: "SKU-B", Quantity: 1}}
+
+	lines := ComparePickedItems(order.PickRequirements(), details)
+	if len(lines) != 2 || !IsPickComplete(lines) {
+		t.Fatalf("expected the components to complete the pick, got %+v", lines)
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"livo-backend-2.0/utils"
+
+	"gorm.io/gorm"
+)
+
+// Order SLA buckets, by time left until the processing limit
+const (
+	SlaStatusOverdue = "overdue"
+	SlaStatusDueSoon = "due_soon"
+	SlaStatusOnTrack = "on_track"
+)
+
+// Order SLA flags set by the SLA checker
+const (
+	SlaFlagAtRisk = "at_risk"
+	SlaFlagMissed = "missed"
+)
+
+// slaLeadTimes is the working time an open order still needs, from its status, until it is handed over to outbound
+var slaLeadTimes = map[string]time.Duration{
+	OrderStatusReadyToPick: 90 * time.Minute,
+	OrderStatusPicking:     75 * time.Minute,
+	OrderStatusPicked:      45 * time.Minute,
+	OrderStatusQC:          30 * time.Minute,
+	OrderStatusPacked:      15 * time.Minute,
+}
+
+// OpenOrderStatuses returns the statuses of orders that still have to be handed over to outbound
+// before their processing limit
+func OpenOrderStatuses() []string {
+	return []string{OrderStatusReadyToPick, OrderStatusPicking, OrderStatusPicked, OrderStatusQC, OrderStatusPacked}
+}
+
+// SlaDueSoonCutoff returns the moment dueWithin working time from now has passed. Processing limits before it are
+// due soon: nights, non working days and holidays do not count, as nobody works on the orders then.
+func SlaDueSoonCutoff(now time.Time, dueWithin time.Duration) time.Time {
+	return utils.BusinessCalendar().AddWorkingDuration(now, dueWithin)
+}
+
+// SlaStatus buckets a processing limit: overdue when it has passed, due soon when it falls before the due soon cutoff
+func SlaStatus(processingLimit, now, dueSoonCutoff time.Time) string {
+	switch {
+	case processingLimit.Before(now):
+		return SlaStatusOverdue
+	case processingLimit.Before(dueSoonCutoff):
+		return SlaStatusDueSoon
+	default:
+		return SlaStatusOnTrack
+	}
+}
+
+// SlaLeadTime returns the working time the order still needs until it is handed over to outbound, zero when it is
+// not open
+func (o *Order) SlaLeadTime() time.Duration {
+	return slaLeadTimes[o.Status]
+}
+
+// SlaCheckResult is the outcome of a run of the SLA checker
+type SlaCheckResult struct {
+	AtRisk  int64 `json:"at_risk"`
+	Missed  int64 `json:"missed"`
+	Cleared int64 `json:"cleared"`
+}
+
+// FlagOrdersAtRisk flags open orders that passed their processing limit as missed, and open orders that cannot be
+// handed over in time at the lead time of their status as at risk. Lead times count working time only, so an order
+// due early in the morning is at risk the evening before. At risk flags of orders that caught up or are no longer
+// open are cleared; missed flags are kept as history. Flags do not touch the updated_at of the orders.
+func FlagOrdersAtRisk(db *gorm.DB, now time.Time) (SlaCheckResult, error) {
+	var result SlaCheckResult
+	calendar := utils.BusinessCalendar()
+
+	missed := db.Model(&Order{}).
+		Where("status IN ? AND processing_limit < ?", OpenOrderStatuses(), now).
+		Where("sla_flag IS NULL OR sla_flag <> ?", SlaFlagMissed).
+		UpdateColumns(map[string]interface{}{"sla_flag": SlaFlagMissed, "sla_flagged_at": now})
+	if missed.Error != nil {
+		return result, missed.Error
+	}
+	result.Missed = missed.RowsAffected
+
+	for status, leadTime := range slaLeadTimes {
+		readyAt := calendar.AddWorkingDuration(now, leadTime)
+
+		atRisk := db.Model(&Order{}).
+			Where("status = ? AND processing_limit >= ? AND processing_limit < ?", status, now, readyAt).
+			Where("sla_flag IS NULL").
+			UpdateColumns(map[string]interface{}{"sla_flag": SlaFlagAtRisk, "sla_flagged_at": now})
+		if atRisk.Error != nil {
+			return result, atRisk.Error
+		}
+		result.AtRisk += atRisk.RowsAffected
+
+		cleared := db.Model(&Order{}).
+			Where("status = ? AND processing_limit >= ?", status, readyAt).
+			Where("sla_flag = ?", SlaFlagAtRisk).
+			UpdateColumns(map[string]interface{}{"sla_flag": nil, "sla_flagged_at": nil})
+		if cleared.Error != nil {
+			return result, cleared.Error
+		}
+		result.Cleared += cleared.RowsAffected
+	}
+
+	// Orders that left the open statuses, handed over or cancelled, are no longer at risk
+	closed := db.Model(&Order{}).
+		Where("status NOT IN ?", OpenOrderStatuses()).
+		Where("sla_flag = ?", SlaFlagAtRisk).
+		UpdateColumns(map[string]interface{}{"sla_flag": nil, "sla_flagged_at": nil})
+	if closed.Error != nil {
+		return result, closed.Error
+	}
+	result.Cleared += closed.RowsAffected
+
+	return result, nil
+}
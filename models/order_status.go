@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Order lifecycle statuses
+const (
+	OrderStatusReadyToPick = "ready to pick"
+	OrderStatusPicking     = "picking"
+	OrderStatusPicked      = "picked"
+	OrderStatusQC          = "qc"
+	OrderStatusPacked      = "packed"
+	OrderStatusOutbound    = "outbound"
+	OrderStatusShipped     = "shipped"
+	OrderStatusCancelled   = "cancelled"
+	OrderStatusReturned    = "returned"
+)
+
+// GetOrderStatusTransitions returns the allowed next statuses for every order status
+func GetOrderStatusTransitions() map[string][]string {
+	return map[string][]string{
+		OrderStatusReadyToPick: {OrderStatusPicking, OrderStatusCancelled},
+		OrderStatusPicking:     {OrderStatusPicked, OrderStatusReadyToPick, OrderStatusCancelled},
+		OrderStatusPicked:      {OrderStatusQC, OrderStatusReadyToPick, OrderStatusCancelled},
+		OrderStatusQC:          {OrderStatusPacked, OrderStatusCancelled},
+		OrderStatusPacked:      {OrderStatusOutbound, OrderStatusCancelled},
+		OrderStatusOutbound:    {OrderStatusShipped, OrderStatusCancelled},
+		OrderStatusShipped:     {OrderStatusReturned},
+		OrderStatusCancelled:   {},
+		OrderStatusReturned:    {},
+	}
+}
+
+// orderStatusStationEndpoints are the endpoints that move an order to a status. The station books its own records
+// and stock movements along with the status, so these statuses are never set by hand. Orders can be sent back to
+// "ready to pick" by hand to correct a stuck claim or a wrong pick.
+var orderStatusStationEndpoints = map[string]string{
+	OrderStatusPicking:   "POST /api/pick-orders/claim",
+	OrderStatusPicked:    "POST /api/pick-orders/{id}/complete",
+	OrderStatusQC:        "POST /api/qc-online or POST /api/qc-ribbon",
+	OrderStatusPacked:    "POST /api/qc-online or POST /api/qc-ribbon",
+	OrderStatusOutbound:  "POST /api/outbounds",
+	OrderStatusShipped:   "PUT /api/manifests/{id}/close",
+	OrderStatusCancelled: "PUT /api/orders/{id}/cancel",
+	OrderStatusReturned:  "PUT /api/returns/{id}/process",
+}
+
+// OrderStatusStationEndpoint returns the endpoint that moves an order to the status, or an empty string when the
+// status may be set by hand
+func OrderStatusStationEndpoint(status string) string {
+	return orderStatusStationEndpoints[status]
+}
+
+// IsValidOrderStatus checks if a status is part of the order lifecycle
+func IsValidOrderStatus(status string) bool {
+	_, exists := GetOrderStatusTransitions()[status]
+	return exists
+}
+
+// AllowedNextStatuses returns the statuses the order can move to from its current status
+func (o *Order) AllowedNextStatuses() []string {
+	return GetOrderStatusTransitions()[o.Status]
+}
+
+// CanTransitionTo checks if the order can move to the given status
+func (o *Order) CanTransitionTo(status string) bool {
+	for _, next := range o.AllowedNextStatuses() {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the order to the given status and stamps the actor and timestamp columns.
+// The caller is responsible for saving the order.
+func (o *Order) TransitionTo(status string, actorID *uint) error {
+	if !IsValidOrderStatus(status) {
+		return fmt.Errorf("unknown order status '%s'", status)
+	}
+
+	if !o.CanTransitionTo(status) {
+		allowed := o.AllowedNextStatuses()
+		if len(allowed) == 0 {
+			return fmt.Errorf("order status '%s' is final and cannot be changed", o.Status)
+		}
+		return fmt.Errorf("cannot change order status from '%s' to '%s', allowed next statuses: %s", o.Status, status, strings.Join(allowed, ", "))
+	}
+
+	now := time.Now()
+	switch status {
+	case OrderStatusPicking:
+		o.PickerID = actorID
+	case OrderStatusPicked:
+		if actorID != nil {
+			o.PickerID = actorID
+		}
+		o.PickedAt = &now
+	case OrderStatusReadyToPick:
+		// Releasing a claimed order clears the picker
+		o.PickerID = nil
+		o.PickedAt = nil
+	case OrderStatusCancelled:
+		o.CancelerID = actorID
+		o.CancelAt = &now
+	}
+
+	if status != OrderStatusPicking && status != OrderStatusPicked && status != OrderStatusCancelled {
+		o.UpdaterID = actorID
+	}
+
+	o.Status = status
+	return nil
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestGetOrderStatusTransitionsCoversEveryStatus(t *testing.T) {
+	transitions := GetOrderStatusTransitions()
+
+	statuses := []string{
+		OrderStatusReadyToPick, OrderStatusPicking, OrderStatusPicked, OrderStatusQC, OrderStatusPacked,
+		OrderStatusOutbound, OrderStatusShipped, OrderStatusCancelled, OrderStatusReturned,
+	}
+	if len(transitions) != len(statuses) {
+		t.Fatalf("expected %d statuses, got %d", len(statuses), len(transitions))
+	}
+
+	for _, status := range statuses {
+		next, ok := transitions[status]
+		if !ok {
+			t.Fatalf("status '%s' has no transitions entry", status)
+		}
+		for _, n := range next {
+			if _, ok := transitions[n]; !ok {
+				t.Errorf("status '%s' moves to unknown status '%s'", status, n)
+			}
+		}
+	}
+
+	for _, final := range []string{OrderStatusCancelled, OrderStatusReturned} {
+		if len(transitions[final]) != 0 {
+			t.Errorf("status '%s' should be final, got %v", final, transitions[final])
+		}
+	}
+}
+
+func TestOrderCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{OrderStatusReadyToPick, OrderStatusPicking, true},
+		{OrderStatusReadyToPick, OrderStatusPicked, false},
+		{OrderStatusPicking, OrderStatusReadyToPick, true},
+		{OrderStatusPicked, OrderStatusReadyToPick, true},
+		{OrderStatusPicked, OrderStatusPacked, false},
+		{OrderStatusQC, OrderStatusReadyToPick, false},
+		{OrderStatusOutbound, OrderStatusCancelled, true},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusShipped, OrderStatusReturned, true},
+		{OrderStatusCancelled, OrderStatusReadyToPick, false},
+	}
+
+	for _, tt := range tests {
+		order := Order{Status: tt.from}
+		if got := order.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("'%s' -> '%s': got %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestOrderTransitionToStampsActorColumns(t *testing.T) {
+	actor := uintPtr(7)
+
+	tests := []struct {
+		name         string
+		from         string
+		to           string
+		wantPicker   *uint
+		wantUpdater  *uint
+		wantCanceler *uint
+		wantPickedAt bool
+		wantCancelAt bool
+	}{
+		{name: "claim", from: OrderStatusReadyToPick, to: OrderStatusPicking, wantPicker: actor},
+		{name: "pick", from: OrderStatusPicking, to: OrderStatusPicked, wantPicker: actor, wantPickedAt: true},
+		{name: "release", from: OrderStatusPicking, to: OrderStatusReadyToPick, wantUpdater: actor},
+		{name: "repick", from: OrderStatusPicked, to: OrderStatusReadyToPick, wantUpdater: actor},
+		{name: "qc", from: OrderStatusPicked, to: OrderStatusQC, wantPicker: uintPtr(3), wantUpdater: actor, wantPickedAt: true},
+		{name: "cancel", from: OrderStatusPacked, to: OrderStatusCancelled, wantPicker: uintPtr(3), wantCanceler: actor, wantPickedAt: true, wantCancelAt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{Status: tt.from}
+			if tt.from != OrderStatusReadyToPick {
+				// Orders past the claim carry their picker
+				order.PickerID = uintPtr(3)
+			}
+			if tt.from == OrderStatusPicked || tt.from == OrderStatusPacked {
+				pickedAt := time.Now()
+				order.PickedAt = &pickedAt
+			}
+
+			if err := order.TransitionTo(tt.to, actor); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if order.Status != tt.to {
+				t.Errorf("status: got '%s', want '%s'", order.Status, tt.to)
+			}
+			assertUintPtr(t, "picker_id", order.PickerID, tt.wantPicker)
+			assertUintPtr(t, "updater_id", order.UpdaterID, tt.wantUpdater)
+			assertUintPtr(t, "canceler_id", order.CancelerID, tt.wantCanceler)
+			if (order.PickedAt != nil) != tt.wantPickedAt {
+				t.Errorf("picked_at: got %v, want set=%v", order.PickedAt, tt.wantPickedAt)
+			}
+			if (order.CancelAt != nil) != tt.wantCancelAt {
+				t.Errorf("cancel_at: got %v, want set=%v", order.CancelAt, tt.wantCancelAt)
+			}
+		})
+	}
+}
+
+func TestOrderTransitionToRejectsIllegalMoves(t *testing.T) {
+	order := Order{Status: OrderStatusQC}
+	err := order.TransitionTo(OrderStatusReadyToPick, uintPtr(1))
+	if err == nil {
+		t.Fatal("expected an error for 'qc' -> 'ready to pick'")
+	}
+	if !strings.Contains(err.Error(), OrderStatusPacked) || !strings.Contains(err.Error(), OrderStatusCancelled) {
+		t.Errorf("error should list the allowed next statuses, got: %v", err)
+	}
+	if order.Status != OrderStatusQC || order.UpdaterID != nil {
+		t.Errorf("order should be left untouched, got status '%s' updater %v", order.Status, order.UpdaterID)
+	}
+
+	final := Order{Status: OrderStatusReturned}
+	if err := final.TransitionTo(OrderStatusShipped, uintPtr(1)); err == nil || !strings.Contains(err.Error(), "final") {
+		t.Errorf("expected a final status error, got: %v", err)
+	}
+
+	unknown := Order{Status: OrderStatusReadyToPick}
+	if err := unknown.TransitionTo("lost", uintPtr(1)); err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("expected an unknown status error, got: %v", err)
+	}
+}
+
+func assertUintPtr(t *testing.T, column string, got, want *uint) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s: got %v, want %v", column, got, want)
+	case *got != *want:
+		t.Errorf("%s: got %d, want %d", column, *got, *want)
+	}
+}
@@ -14,6 +14,7 @@ type Outbound struct {
 	ExpeditionColor string         `gorm:"not null" json:"expedition_color"`
 	ExpeditionSlug  string         `gorm:"not null" json:"expedition_slug" `
 	Complained      bool           `gorm:"default:false" json:"complained"`
+	ManifestID      *uint          `gorm:"default:null;index" json:"manifest_id"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
@@ -31,6 +32,7 @@ type OutboundResponse struct {
 	ExpeditionColor string    `json:"expedition_color"`
 	ExpeditionSlug  string    `json:"expedition_slug"`
 	Complained      bool      `json:"complained"`
+	ManifestID      *uint     `json:"manifest_id"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 
@@ -49,6 +51,7 @@ func (ob *Outbound) ToOutboundResponse() OutboundResponse {
 		ExpeditionColor: ob.ExpeditionColor,
 		ExpeditionSlug:  ob.ExpeditionSlug,
 		Complained:      ob.Complained,
+		ManifestID:      ob.ManifestID,
 		CreatedAt:       ob.CreatedAt,
 		UpdatedAt:       ob.UpdatedAt,
 	}
@@ -67,3 +70,13 @@ func (ob *Outbound) ToOutboundResponse() OutboundResponse {
 
 	return response
 }
+
+// ToOutboundResponses converts a slice of Outbound models to responses
+func ToOutboundResponses(outbounds []Outbound) []OutboundResponse {
+	responses := make([]OutboundResponse, len(outbounds))
+	for i, ob := range outbounds {
+		responses[i] = ob.ToOutboundResponse()
+	}
+
+	return responses
+}
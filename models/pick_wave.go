@@ -0,0 +1,138 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Pick wave statuses
+const (
+	PickWaveStatusPicking   = "picking"
+	PickWaveStatusCompleted = "completed"
+)
+
+// PickWave groups several orders that are picked together in one batch
+type PickWave struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Code        string         `gorm:"unique;not null" json:"code"`
+	Status      string         `gorm:"not null;index" json:"status"`
+	PickerID    uint           `gorm:"not null;index" json:"picker_id"`
+	CompletedAt *time.Time     `gorm:"default:null" json:"completed_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Associations
+	Picker     *User       `gorm:"foreignKey:PickerID" json:"picker,omitempty"`
+	PickOrders []PickOrder `gorm:"foreignKey:PickWaveID" json:"pick_orders"`
+}
+
+// PickListLine is a consolidated line of a wave pick list
+type PickListLine struct {
+	Sku         string `json:"sku"`
+	ProductName string `json:"product_name"`
+	Variant     string `json:"variant"`
+	Location    string `json:"location"`
+	Quantity    int    `json:"quantity"`
+	Orders      int    `json:"orders"`
+}
+
+type PickWaveResponse struct {
+	ID          uint                `json:"id"`
+	Code        string              `json:"code"`
+	Status      string              `json:"status"`
+	PickerID    uint                `json:"picker_id"`
+	CompletedAt *time.Time          `json:"completed_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Picker      *UserResponse       `json:"picker,omitempty"`
+	PickOrders  []PickOrderResponse `json:"pick_orders"`
+	PickList    []PickListLine      `json:"pick_list"`
+}
+
+// ToPickWaveResponse converts PickWave model to PickWaveResponse
+func (pw *PickWave) ToPickWaveResponse() PickWaveResponse {
+	pickOrders := make([]PickOrderResponse, len(pw.PickOrders))
+	for i, po := range pw.PickOrders {
+		pickOrders[i] = po.ToPickOrderResponse()
+	}
+
+	response := PickWaveResponse{
+		ID:          pw.ID,
+		Code:        pw.Code,
+		Status:      pw.Status,
+		PickerID:    pw.PickerID,
+		CompletedAt: pw.CompletedAt,
+		CreatedAt:   pw.CreatedAt,
+		UpdatedAt:   pw.UpdatedAt,
+		PickOrders:  pickOrders,
+	}
+
+	// Include picker data if loaded
+	if pw.Picker != nil {
+		pickerResp := pw.Picker.ToUserResponse()
+		response.Picker = &pickerResp
+	}
+
+	return response
+}
+
+// BuildPickList aggregates the requirements of all wave orders per SKU and sorts the lines by product location.
+// Pick orders must be loaded with their Order and OrderDetails.
+func (pw *PickWave) BuildPickList(db *gorm.DB) []PickListLine {
+	var lines []PickListLine
+	index := make(map[string]int)
+
+	for _, po := range pw.PickOrders {
+		if po.Order == nil {
+			continue
+		}
+		for _, req := range po.Order.PickRequirements() {
+			if i, exists := index[req.Sku]; exists {
+				lines[i].Quantity += req.Quantity
+				lines[i].Orders++
+				continue
+			}
+
+			index[req.Sku] = len(lines)
+			lines = append(lines, PickListLine{
+				Sku:         req.Sku,
+				ProductName: req.ProductName,
+				Variant:     req.Variant,
+				Quantity:    req.Quantity,
+				Orders:      1,
+			})
+		}
+	}
+
+	// Load product locations in one query
+	if len(lines) > 0 {
+		skus := make([]string, len(lines))
+		for i, line := range lines {
+			skus[i] = line.Sku
+		}
+
+		var products []Product
+		db.Where("sku IN ?", skus).Find(&products)
+		for _, product := range products {
+			if i, exists := index[product.Sku]; exists {
+				lines[i].Location = product.Location
+			}
+		}
+	}
+
+	// Sort by location so the picker walks the warehouse once, unknown locations last
+	sort.SliceStable(lines, func(i, j int) bool {
+		if (lines[i].Location == "") != (lines[j].Location == "") {
+			return lines[i].Location != ""
+		}
+		if lines[i].Location != lines[j].Location {
+			return lines[i].Location < lines[j].Location
+		}
+		return lines[i].Sku < lines[j].Sku
+	})
+
+	return lines
+}
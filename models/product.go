@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,27 +14,31 @@ type Product struct {
 	Image     string         `json:"image"`
 	Variant   string         `json:"variant"`
 	Location  string         `json:"location"`
-	Barcode   string         `json:"barcode"`
+	Barcode   string         `gorm:"index" json:"barcode"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	BundleItems []ProductBundleItem `json:"bundle_items,omitempty" gorm:"-"`
 }
 
 type ProductResponse struct {
-	ID       uint      `json:"id"`
-	Sku      string    `json:"sku"`
-	Name     string    `json:"name"`
-	Image    string    `json:"image"`
-	Variant  string    `json:"variant"`
-	Location string    `json:"location"`
-	Barcode  string    `json:"barcode"`
-	Created  time.Time `json:"created_at"`
-	Updated  time.Time `json:"updated_at"`
+	ID          uint                        `json:"id"`
+	Sku         string                      `json:"sku"`
+	Name        string                      `json:"name"`
+	Image       string                      `json:"image"`
+	Variant     string                      `json:"variant"`
+	Location    string                      `json:"location"`
+	Barcode     string                      `json:"barcode"`
+	IsBundle    bool                        `json:"is_bundle"`
+	BundleItems []ProductBundleItemResponse `json:"bundle_items,omitempty"`
+	Created     time.Time                   `json:"created_at"`
+	Updated     time.Time                   `json:"updated_at"`
 }
 
 // ToProductResponse converts Product model to ProductResponse
 func (p *Product) ToProductResponse() ProductResponse {
-	return ProductResponse{
+	response := ProductResponse{
 		ID:       p.ID,
 		Sku:      p.Sku,
 		Name:     p.Name,
@@ -44,4 +49,37 @@ func (p *Product) ToProductResponse() ProductResponse {
 		Created:  p.CreatedAt,
 		Updated:  p.UpdatedAt,
 	}
+
+	// Include bundle components if loaded
+	if len(p.BundleItems) > 0 {
+		response.IsBundle = true
+		response.BundleItems = make([]ProductBundleItemResponse, len(p.BundleItems))
+		for i, item := range p.BundleItems {
+			response.BundleItems[i] = item.ToProductBundleItemResponse()
+		}
+	}
+
+	return response
+}
+
+// ErrEmptyBarcode is returned by FindProductByBarcode for an empty code
+var ErrEmptyBarcode = errors.New("barcode must not be empty")
+
+// FindProductByBarcode resolves a scanned code to a product, matching the barcode first and the SKU second.
+// An empty code is rejected with ErrEmptyBarcode, since products without a barcode store an empty one.
+func FindProductByBarcode(db *gorm.DB, code string) (*Product, error) {
+	if code == "" {
+		return nil, ErrEmptyBarcode
+	}
+
+	var product Product
+	err := db.Where("barcode = ?", code).First(&product).Error
+	if err == gorm.ErrRecordNotFound {
+		err = db.Where("sku = ?", code).First(&product).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
 }
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductBundleItem is one component of a bundle product: selling one BundleSku means picking Quantity of ComponentSku.
+// Components are plain products; bundles cannot be nested.
+type ProductBundleItem struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	BundleSku    string    `gorm:"not null;uniqueIndex:idx_bundle_component" json:"bundle_sku"`
+	ComponentSku string    `gorm:"not null;uniqueIndex:idx_bundle_component;index" json:"component_sku"`
+	Quantity     int       `gorm:"not null" json:"quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Component    *Product  `json:"component,omitempty" gorm:"-"`
+}
+
+type ProductBundleItemResponse struct {
+	ComponentSku string `json:"component_sku"`
+	ProductName  string `json:"product_name"`
+	Variant      string `json:"variant"`
+	Quantity     int    `json:"quantity"`
+}
+
+// ToProductBundleItemResponse converts ProductBundleItem model to ProductBundleItemResponse
+func (pbi *ProductBundleItem) ToProductBundleItemResponse() ProductBundleItemResponse {
+	response := ProductBundleItemResponse{
+		ComponentSku: pbi.ComponentSku,
+		Quantity:     pbi.Quantity,
+	}
+
+	// Include component data if loaded
+	if pbi.Component != nil {
+		response.ProductName = pbi.Component.Name
+		response.Variant = pbi.Component.Variant
+	}
+
+	return response
+}
+
+// FindBundleItems returns the components of the given bundle SKUs grouped by bundle SKU, with their products loaded
+func FindBundleItems(db *gorm.DB, bundleSkus []string) (map[string][]ProductBundleItem, error) {
+	bundles := make(map[string][]ProductBundleItem)
+	if len(bundleSkus) == 0 {
+		return bundles, nil
+	}
+
+	var items []ProductBundleItem
+	if err := db.Where("bundle_sku IN ?", bundleSkus).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	componentSkus := make([]string, len(items))
+	for i, item := range items {
+		componentSkus[i] = item.ComponentSku
+	}
+
+	products := make(map[string]Product)
+	if len(componentSkus) > 0 {
+		var found []Product
+		if err := db.Where("sku IN ?", componentSkus).Find(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, product := range found {
+			products[product.Sku] = product
+		}
+	}
+
+	for _, item := range items {
+		if product, exists := products[item.ComponentSku]; exists {
+			item.Component = &product
+		}
+		bundles[item.BundleSku] = append(bundles[item.BundleSku], item)
+	}
+
+	return bundles, nil
+}
+
+// LoadBundleItems loads the components of the product when it is a bundle
+func (p *Product) LoadBundleItems(db *gorm.DB) error {
+	bundles, err := FindBundleItems(db, []string{p.Sku})
+	if err != nil {
+		return err
+	}
+
+	p.BundleItems = bundles[p.Sku]
+	return nil
+}
+
+// ExplodeBundles turns the top-level order details whose SKU is a bundle into bundle lines and creates
+// one component detail per bundle component, multiplied by the bundle quantity. The order details must be saved.
+// Bundles of all given orders are looked up and their components created at once; created components are appended
+// to the order details.
+func ExplodeBundles(tx *gorm.DB, orders ...*Order) error {
+	var skus []string
+	for _, order := range orders {
+		for _, detail := range order.OrderDetails {
+			if detail.BundleDetailID == nil && !detail.IsBundle {
+				skus = append(skus, detail.Sku)
+			}
+		}
+	}
+
+	bundles, err := FindBundleItems(tx, skus)
+	if err != nil {
+		return err
+	}
+	if len(bundles) == 0 {
+		return nil
+	}
+
+	var bundleDetailIDs []uint
+	var components []OrderDetail
+	for _, order := range orders {
+		for i := range order.OrderDetails {
+			detail := &order.OrderDetails[i]
+			items, isBundle := bundles[detail.Sku]
+			if !isBundle || detail.BundleDetailID != nil || detail.IsBundle {
+				continue
+			}
+
+			detail.IsBundle = true
+			bundleDetailIDs = append(bundleDetailIDs, detail.ID)
+			components = append(components, bundleComponents(detail, items)...)
+		}
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&OrderDetail{}).Where("id IN ?", bundleDetailIDs).Update("is_bundle", true).Error; err != nil {
+		return err
+	}
+	if err := tx.CreateInBatches(&components, 1000).Error; err != nil {
+		return err
+	}
+
+	// Components are appended to the order they belong to
+	byOrder := make(map[uint][]OrderDetail)
+	for _, component := range components {
+		byOrder[component.OrderID] = append(byOrder[component.OrderID], component)
+	}
+	for _, order := range orders {
+		order.OrderDetails = append(order.OrderDetails, byOrder[order.ID]...)
+	}
+
+	return nil
+}
+
+// ExplodeBundleDetail explodes a single saved order detail when its SKU is a bundle and returns the created components
+func ExplodeBundleDetail(tx *gorm.DB, detail *OrderDetail) ([]OrderDetail, error) {
+	bundles, err := FindBundleItems(tx, []string{detail.Sku})
+	if err != nil {
+		return nil, err
+	}
+
+	items, isBundle := bundles[detail.Sku]
+	if !isBundle {
+		return nil, nil
+	}
+
+	return explodeBundleDetail(tx, detail, items)
+}
+
+// explodeBundleDetail marks the detail as bundle line and creates its component details
+func explodeBundleDetail(tx *gorm.DB, detail *OrderDetail, items []ProductBundleItem) ([]OrderDetail, error) {
+	detail.IsBundle = true
+	if err := tx.Model(detail).Update("is_bundle", true).Error; err != nil {
+		return nil, err
+	}
+
+	components := bundleComponents(detail, items)
+	if err := tx.Create(&components).Error; err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// bundleComponents builds the unsaved component details of a saved bundle line
+func bundleComponents(detail *OrderDetail, items []ProductBundleItem) []OrderDetail {
+	bundleDetailID := detail.ID
+	components := make([]OrderDetail, len(items))
+	for i, item := range items {
+		components[i] = OrderDetail{
+			OrderID:        detail.OrderID,
+			BundleDetailID: &bundleDetailID,
+			Sku:            item.ComponentSku,
+			ProductName:    item.ComponentSku,
+			Quantity:       item.Quantity * detail.Quantity,
+		}
+		if item.Component != nil {
+			components[i].ProductName = item.Component.Name
+			components[i].Variant = item.Component.Variant
+		}
+	}
+	return components
+}
+
+// RemoveBundleComponents deletes the component details of a bundle line and turns it back into a plain line
+func RemoveBundleComponents(tx *gorm.DB, detail *OrderDetail) error {
+	if err := tx.Where("bundle_detail_id = ?", detail.ID).Delete(&OrderDetail{}).Error; err != nil {
+		return err
+	}
+
+	detail.IsBundle = false
+	return tx.Model(detail).Update("is_bundle", false).Error
+}
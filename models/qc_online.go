@@ -107,25 +107,6 @@ func (qco *QcOnline) ToQcOnlineResponse() QcOnlineResponse {
 	return response
 }
 
-// LoadOrder manually loads the related order by tracking number
-func (qco *QcOnline) LoadOrder(db *gorm.DB) error {
-	if qco.Tracking == "" {
-		return nil
-	}
-
-	var order Order
-	if err := db.Where("tracking = ?", qco.Tracking).
-		Preload("OrderDetails").
-		Preload("Picker.UserRoles.Role").
-		Preload("Picker.UserRoles.Assigner").
-		First(&order).Error; err != nil {
-		return err
-	}
-
-	qco.Order = &order
-	return nil
-}
-
 // Helper method to convert multiple QcOnline to responses
 func ToQcOnlineResponses(qcOnlines []QcOnline) []QcOnlineResponse {
 	responses := make([]QcOnlineResponse, len(qcOnlines))
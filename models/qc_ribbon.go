@@ -107,24 +107,6 @@ func (qcr *QcRibbon) ToQcRibbonResponse() QcRibbonResponse {
 	return response
 }
 
-// LoadOrder manually loads the related order by tracking number
-func (qcr *QcRibbon) LoadOrder(db *gorm.DB) error {
-	if qcr.Tracking == "" {
-		return nil
-	}
-
-	var order Order
-	if err := db.Where("tracking = ?", qcr.Tracking).Preload("OrderDetails").
-		Preload("Picker.UserRoles.Role").
-		Preload("Picker.UserRoles.Assigner").
-		First(&order).Error; err != nil {
-		return err
-	}
-
-	qcr.Order = &order
-	return nil
-}
-
 // Helper method to convert multiple QcRibbon to responses
 func ToQcRibbonResponses(qcRibbons []QcRibbon) []QcRibbonResponse {
 	responses := make([]QcRibbonResponse, len(qcRibbons))
@@ -6,6 +6,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// Return detail outcomes decided at intake
+const (
+	ReturnOutcomeRestock = "restock"
+	ReturnOutcomeScrap   = "scrap"
+)
+
 type Return struct {
 	ID           uint           `gorm:"primaryKey" json:"id"`
 	NewTracking  string         `gorm:"index" json:"new_tracking"`
@@ -17,6 +23,9 @@ type Return struct {
 	ReturnReason string         `json:"return_reason"`
 	ReturnNumber string         `json:"return_number"`
 	ScrapNumber  string         `json:"scrap_number"`
+	CreatorID    *uint          `gorm:"default:null" json:"creator_id"`
+	ProcessorID  *uint          `gorm:"default:null" json:"processor_id"`
+	ProcessedAt  *time.Time     `gorm:"default:null" json:"processed_at"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -33,6 +42,7 @@ type ReturnDetail struct {
 	ReturnID  uint           `gorm:"not null" json:"return_id"`
 	ProductID uint           `gorm:"not null" json:"product_id"`
 	Quantity  int            `gorm:"not null" json:"quantity"`
+	Outcome   string         `gorm:"default:null" json:"outcome"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -48,6 +58,7 @@ type ReturnDetailResponse struct {
 	ReturnID  uint            `json:"return_id"`
 	ProductID uint            `json:"product_id"`
 	Quantity  int             `json:"quantity"`
+	Outcome   string          `json:"outcome"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 	Product   ProductResponse `json:"product"`
@@ -64,6 +75,9 @@ type ReturnResponse struct {
 	ReturnReason  string                 `json:"return_reason"`
 	ReturnNumber  string                 `json:"return_number"`
 	ScrapNumber   string                 `json:"scrap_number"`
+	CreatorID     *uint                  `json:"creator_id"`
+	ProcessorID   *uint                  `json:"processor_id"`
+	ProcessedAt   *time.Time             `json:"processed_at"`
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
 	ReturnDetails []ReturnDetailResponse `json:"return_details"`
@@ -98,6 +112,7 @@ func (r *Return) ToReturnResponse() ReturnResponse {
 			ReturnID:  detail.ReturnID,
 			ProductID: detail.ProductID,
 			Quantity:  detail.Quantity,
+			Outcome:   detail.Outcome,
 			CreatedAt: detail.CreatedAt,
 			UpdatedAt: detail.UpdatedAt,
 		}
@@ -121,6 +136,9 @@ func (r *Return) ToReturnResponse() ReturnResponse {
 		ReturnReason:  r.ReturnReason,
 		ReturnNumber:  r.ReturnNumber,
 		ScrapNumber:   r.ScrapNumber,
+		CreatorID:     r.CreatorID,
+		ProcessorID:   r.ProcessorID,
+		ProcessedAt:   r.ProcessedAt,
 		CreatedAt:     r.CreatedAt,
 		UpdatedAt:     r.UpdatedAt,
 		ReturnDetails: detailResponses,
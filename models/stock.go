@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Stock movement types
+const (
+	StockMovementReceipt       = "receipt"
+	StockMovementPick          = "pick"
+	StockMovementReturnRestock = "return_restock"
+	StockMovementCancelRestock = "cancel_restock"
+	StockMovementScrap         = "scrap"
+	StockMovementAdjustment    = "adjustment"
+)
+
+// ScrapLocation is the bin holding scrapped returned goods, kept apart from sellable stock
+const ScrapLocation = "SCRAP"
+
+// Stock is the quantity on hand of a SKU in one bin. It is only changed through RecordStockMovement.
+// Quantities can go negative when more is picked than was recorded, which is resolved by a stock opname.
+type Stock struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Sku       string    `gorm:"not null;uniqueIndex:idx_stock_sku_location" json:"sku"`
+	Location  string    `gorm:"not null;default:'';uniqueIndex:idx_stock_sku_location" json:"location"`
+	Quantity  int       `gorm:"not null;default:0" json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StockMovement is an append-only ledger entry of a stock change. Quantity is signed.
+type StockMovement struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Sku          string    `gorm:"not null;index" json:"sku"`
+	Location     string    `gorm:"not null;default:''" json:"location"`
+	Type         string    `gorm:"not null;index" json:"type"`
+	Quantity     int       `gorm:"not null" json:"quantity"`
+	BalanceAfter int       `gorm:"not null" json:"balance_after"`
+	Reference    string    `gorm:"default:null;index" json:"reference"`
+	Note         string    `gorm:"default:null" json:"note"`
+	UserID       *uint     `gorm:"default:null" json:"user_id"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+
+	// Associations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+type StockResponse struct {
+	ID          uint      `json:"id"`
+	Sku         string    `json:"sku"`
+	ProductName string    `json:"product_name"`
+	Variant     string    `json:"variant"`
+	Location    string    `json:"location"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type StockMovementResponse struct {
+	ID           uint          `json:"id"`
+	Sku          string        `json:"sku"`
+	Location     string        `json:"location"`
+	Type         string        `json:"type"`
+	Quantity     int           `json:"quantity"`
+	BalanceAfter int           `json:"balance_after"`
+	Reference    string        `json:"reference"`
+	Note         string        `json:"note"`
+	UserID       *uint         `json:"user_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+	User         *UserResponse `json:"user,omitempty"`
+}
+
+// ToStockResponse converts Stock model to StockResponse. The product is optional.
+func (s *Stock) ToStockResponse(product *Product) StockResponse {
+	response := StockResponse{
+		ID:        s.ID,
+		Sku:       s.Sku,
+		Location:  s.Location,
+		Quantity:  s.Quantity,
+		UpdatedAt: s.UpdatedAt,
+	}
+
+	// Include product data if known
+	if product != nil {
+		response.ProductName = product.Name
+		response.Variant = product.Variant
+	}
+
+	return response
+}
+
+// ToStockMovementResponse converts StockMovement model to StockMovementResponse
+func (sm *StockMovement) ToStockMovementResponse() StockMovementResponse {
+	response := StockMovementResponse{
+		ID:           sm.ID,
+		Sku:          sm.Sku,
+		Location:     sm.Location,
+		Type:         sm.Type,
+		Quantity:     sm.Quantity,
+		BalanceAfter: sm.BalanceAfter,
+		Reference:    sm.Reference,
+		Note:         sm.Note,
+		UserID:       sm.UserID,
+		CreatedAt:    sm.CreatedAt,
+	}
+
+	// Include user data if loaded
+	if sm.User != nil {
+		userResp := sm.User.ToUserResponse()
+		response.User = &userResp
+	}
+
+	return response
+}
+
+// RecordStockMovement applies a signed movement to the stock of its SKU and location and appends it to the ledger.
+// It must run inside a transaction; the stock row is locked until the transaction ends.
+func RecordStockMovement(tx *gorm.DB, movement *StockMovement) error {
+	stock := Stock{Sku: movement.Sku, Location: movement.Location}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&stock).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sku = ? AND location = ?", movement.Sku, movement.Location).
+		First(&stock).Error; err != nil {
+		return err
+	}
+
+	stock.Quantity += movement.Quantity
+	if err := tx.Model(&stock).Update("quantity", stock.Quantity).Error; err != nil {
+		return err
+	}
+
+	movement.BalanceAfter = stock.Quantity
+	return tx.Omit(clause.Associations).Create(movement).Error
+}
+
+// ProductLocations returns the default bin (Product.Location) of each given SKU
+func ProductLocations(db *gorm.DB, skus []string) map[string]string {
+	locations := make(map[string]string)
+	if len(skus) == 0 {
+		return locations
+	}
+
+	var products []Product
+	db.Where("sku IN ?", skus).Find(&products)
+	for _, product := range products {
+		locations[product.Sku] = product.Location
+	}
+
+	return locations
+}
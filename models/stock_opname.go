@@ -0,0 +1,248 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Stock opname statuses
+const (
+	StockOpnameStatusCounting  = "counting"
+	StockOpnameStatusCompleted = "completed"
+	StockOpnameStatusCancelled = "cancelled"
+)
+
+// Stock opname line statuses
+const (
+	StockOpnameLinePending  = "pending"
+	StockOpnameLineCounted  = "counted"
+	StockOpnameLineRecount  = "recount"
+	StockOpnameLineApproved = "approved"
+)
+
+// StockOpname is a physical count session of the bins in Locations and/or the SKUs in Skus (comma separated).
+// The session is completed once every line is approved.
+type StockOpname struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Code        string         `gorm:"unique;not null" json:"code"`
+	Status      string         `gorm:"not null;index" json:"status"`
+	Locations   string         `gorm:"default:null" json:"locations"`
+	Skus        string         `gorm:"default:null" json:"skus"`
+	Notes       string         `gorm:"default:null" json:"notes"`
+	CreatorID   uint           `gorm:"not null" json:"creator_id"`
+	CompletedAt *time.Time     `gorm:"default:null" json:"completed_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Associations
+	Creator *User             `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	Lines   []StockOpnameLine `gorm:"foreignKey:StockOpnameID" json:"lines"`
+}
+
+// StockOpnameLine is the count of one SKU in one bin. ExpectedQuantity is the ledger balance taken when the
+// line is first counted, so stock moved before the count does not show up as variance.
+type StockOpnameLine struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	StockOpnameID    uint       `gorm:"not null;uniqueIndex:idx_stock_opname_line" json:"stock_opname_id"`
+	Sku              string     `gorm:"not null;uniqueIndex:idx_stock_opname_line" json:"sku"`
+	Location         string     `gorm:"not null;default:'';uniqueIndex:idx_stock_opname_line" json:"location"`
+	ProductName      string     `json:"product_name"`
+	Variant          string     `json:"variant"`
+	Barcode          string     `json:"barcode"`
+	Status           string     `gorm:"not null;index" json:"status"`
+	ExpectedQuantity *int       `gorm:"default:null" json:"expected_quantity"`
+	CountedQuantity  *int       `gorm:"default:null" json:"counted_quantity"`
+	CounterID        *uint      `gorm:"default:null" json:"counter_id"`
+	CountedAt        *time.Time `gorm:"default:null" json:"counted_at"`
+	ApproverID       *uint      `gorm:"default:null" json:"approver_id"`
+	ApprovedAt       *time.Time `gorm:"default:null" json:"approved_at"`
+	MovementID       *uint      `gorm:"default:null" json:"movement_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Associations
+	Counter  *User `gorm:"foreignKey:CounterID" json:"counter,omitempty"`
+	Approver *User `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+}
+
+// StockOpnameProgress counts the lines of a session per status
+type StockOpnameProgress struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Counted   int `json:"counted"`
+	Recount   int `json:"recount"`
+	Approved  int `json:"approved"`
+	Variances int `json:"variances"`
+}
+
+type StockOpnameResponse struct {
+	ID          uint                      `json:"id"`
+	Code        string                    `json:"code"`
+	Status      string                    `json:"status"`
+	Locations   []string                  `json:"locations"`
+	Skus        []string                  `json:"skus"`
+	Notes       string                    `json:"notes"`
+	CreatorID   uint                      `json:"creator_id"`
+	CompletedAt *time.Time                `json:"completed_at"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+	Progress    StockOpnameProgress       `json:"progress"`
+	Creator     *UserResponse             `json:"creator,omitempty"`
+	Lines       []StockOpnameLineResponse `json:"lines,omitempty"`
+}
+
+type StockOpnameLineResponse struct {
+	ID               uint          `json:"id"`
+	StockOpnameID    uint          `json:"stock_opname_id"`
+	Sku              string        `json:"sku"`
+	Location         string        `json:"location"`
+	ProductName      string        `json:"product_name"`
+	Variant          string        `json:"variant"`
+	Barcode          string        `json:"barcode"`
+	Status           string        `json:"status"`
+	ExpectedQuantity *int          `json:"expected_quantity"`
+	CountedQuantity  *int          `json:"counted_quantity"`
+	Variance         *int          `json:"variance"`
+	CounterID        *uint         `json:"counter_id"`
+	CountedAt        *time.Time    `json:"counted_at"`
+	ApproverID       *uint         `json:"approver_id"`
+	ApprovedAt       *time.Time    `json:"approved_at"`
+	MovementID       *uint         `json:"movement_id"`
+	Counter          *UserResponse `json:"counter,omitempty"`
+	Approver         *UserResponse `json:"approver,omitempty"`
+}
+
+// ToStockOpnameResponse converts StockOpname model to StockOpnameResponse
+func (so *StockOpname) ToStockOpnameResponse() StockOpnameResponse {
+	response := StockOpnameResponse{
+		ID:          so.ID,
+		Code:        so.Code,
+		Status:      so.Status,
+		Locations:   SplitStockOpnameScope(so.Locations),
+		Skus:        SplitStockOpnameScope(so.Skus),
+		Notes:       so.Notes,
+		CreatorID:   so.CreatorID,
+		CompletedAt: so.CompletedAt,
+		CreatedAt:   so.CreatedAt,
+		UpdatedAt:   so.UpdatedAt,
+		Progress:    so.Progress(),
+	}
+
+	// Include creator data if loaded
+	if so.Creator != nil {
+		creatorResp := so.Creator.ToUserResponse()
+		response.Creator = &creatorResp
+	}
+
+	// Include lines if loaded
+	if len(so.Lines) > 0 {
+		response.Lines = make([]StockOpnameLineResponse, len(so.Lines))
+		for i, line := range so.Lines {
+			response.Lines[i] = line.ToStockOpnameLineResponse()
+		}
+	}
+
+	return response
+}
+
+// ToStockOpnameLineResponse converts StockOpnameLine model to StockOpnameLineResponse
+func (sol *StockOpnameLine) ToStockOpnameLineResponse() StockOpnameLineResponse {
+	response := StockOpnameLineResponse{
+		ID:               sol.ID,
+		StockOpnameID:    sol.StockOpnameID,
+		Sku:              sol.Sku,
+		Location:         sol.Location,
+		ProductName:      sol.ProductName,
+		Variant:          sol.Variant,
+		Barcode:          sol.Barcode,
+		Status:           sol.Status,
+		ExpectedQuantity: sol.ExpectedQuantity,
+		CountedQuantity:  sol.CountedQuantity,
+		Variance:         sol.Variance(),
+		CounterID:        sol.CounterID,
+		CountedAt:        sol.CountedAt,
+		ApproverID:       sol.ApproverID,
+		ApprovedAt:       sol.ApprovedAt,
+		MovementID:       sol.MovementID,
+	}
+
+	// Include counter data if loaded
+	if sol.Counter != nil {
+		counterResp := sol.Counter.ToUserResponse()
+		response.Counter = &counterResp
+	}
+
+	// Include approver data if loaded
+	if sol.Approver != nil {
+		approverResp := sol.Approver.ToUserResponse()
+		response.Approver = &approverResp
+	}
+
+	return response
+}
+
+// Variance returns counted minus expected quantity, or nil while the line is not counted
+func (sol *StockOpnameLine) Variance() *int {
+	if sol.ExpectedQuantity == nil || sol.CountedQuantity == nil {
+		return nil
+	}
+	variance := *sol.CountedQuantity - *sol.ExpectedQuantity
+	return &variance
+}
+
+// Progress counts the loaded lines per status and the counted lines with a variance
+func (so *StockOpname) Progress() StockOpnameProgress {
+	progress := StockOpnameProgress{Total: len(so.Lines)}
+	for _, line := range so.Lines {
+		switch line.Status {
+		case StockOpnameLinePending:
+			progress.Pending++
+		case StockOpnameLineCounted:
+			progress.Counted++
+		case StockOpnameLineRecount:
+			progress.Recount++
+		case StockOpnameLineApproved:
+			progress.Approved++
+		}
+		if variance := line.Variance(); variance != nil && *variance != 0 {
+			progress.Variances++
+		}
+	}
+	return progress
+}
+
+// InLocationScope reports whether the location is one of the counted bins of the session
+func (so *StockOpname) InLocationScope(location string) bool {
+	for _, scoped := range SplitStockOpnameScope(so.Locations) {
+		if scoped == location {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinStockOpnameScope trims, deduplicates and joins scope values for storage
+func JoinStockOpnameScope(values []string) string {
+	seen := make(map[string]bool)
+	var scope []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		scope = append(scope, value)
+	}
+	return strings.Join(scope, ",")
+}
+
+// SplitStockOpnameScope splits a stored scope into its values
+func SplitStockOpnameScope(scope string) []string {
+	if scope == "" {
+		return []string{}
+	}
+	return strings.Split(scope, ",")
+}
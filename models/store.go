@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,37 +11,91 @@ type Store struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	Code      string         `gorm:"size:4;not null;unique;check:code_len_check,length(code) >= 2" json:"code"`
 	Name      string         `gorm:"not null;unique" json:"name"`
+	IsRibbon  bool           `gorm:"default:false" json:"is_ribbon"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 type StoreResponse struct {
-	ID      uint      `json:"id"`
-	Code    string    `json:"code"`
-	Name    string    `json:"name"`
-	Created time.Time `json:"created_at"`
-	Updated time.Time `json:"updated_at"`
+	ID       uint      `json:"id"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	IsRibbon bool      `json:"is_ribbon"`
+	Created  time.Time `json:"created_at"`
+	Updated  time.Time `json:"updated_at"`
 }
 
 // ToStoreResponse converts Store model to StoreResponse
 func (s *Store) ToStoreResponse() StoreResponse {
 	return StoreResponse{
-		ID:      s.ID,
-		Code:    s.Code,
-		Name:    s.Name,
-		Created: s.CreatedAt,
-		Updated: s.UpdatedAt,
+		ID:       s.ID,
+		Code:     s.Code,
+		Name:     s.Name,
+		IsRibbon: s.IsRibbon,
+		Created:  s.CreatedAt,
+		Updated:  s.UpdatedAt,
 	}
 }
 
 // ToStoreMobileResponse converts Store model to StoreResponse for mobile use
 func (s *Store) ToStoreMobileResponse() StoreResponse {
 	return StoreResponse{
-		ID:      s.ID,
-		Code:    s.Code,
-		Name:    s.Name,
-		Created: s.CreatedAt,
-		Updated: s.UpdatedAt,
+		ID:       s.ID,
+		Code:     s.Code,
+		Name:     s.Name,
+		IsRibbon: s.IsRibbon,
+		Created:  s.CreatedAt,
+		Updated:  s.UpdatedAt,
 	}
 }
+
+// NormalizeStoreName lowercases a store name and strips everything except letters and digits,
+// so "SP deParcelRibbon" and "DeParcel Ribbon" can be matched against each other
+func NormalizeStoreName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// GetRibbonStores returns all stores whose orders are handled by the ribbon stations
+func GetRibbonStores(db *gorm.DB) ([]Store, error) {
+	var stores []Store
+	err := db.Where("is_ribbon = ?", true).Find(&stores).Error
+	return stores, err
+}
+
+// IsRibbonOrderStore checks if the store name of an order belongs to one of the ribbon stores
+func IsRibbonOrderStore(ribbonStores []Store, orderStore string) bool {
+	normalized := NormalizeStoreName(orderStore)
+	for _, store := range ribbonStores {
+		if name := NormalizeStoreName(store.Name); name != "" && strings.Contains(normalized, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RibbonStoreCondition builds a SQL condition matching the given order store column against the ribbon stores
+func RibbonStoreCondition(ribbonStores []Store, column string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, store := range ribbonStores {
+		name := NormalizeStoreName(store.Name)
+		if name == "" {
+			continue
+		}
+		conditions = append(conditions, "regexp_replace(lower("+column+"), '[^a-z0-9]', '', 'g') LIKE ?")
+		args = append(args, "%"+name+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "1 = 0", nil
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// ResolveOrder finds an order by tracking number, or by Ginee order ID when there is no tracking number, with its
+// details and picker. Every station resolves the order of its records through it, so they all see the same order.
+// It returns nil without an error when both are empty, and gorm.ErrRecordNotFound when no order matches.
+func ResolveOrder(db *gorm.DB, tracking, orderGineeID string) (*Order, error) {
+	query := db.Preload("OrderDetails").
+		Preload("Picker.UserRoles.Role").
+		Preload("Picker.UserRoles.Assigner")
+	switch {
+	case tracking != "":
+		query = query.Where("tracking = ?", tracking)
+	case orderGineeID != "":
+		query = query.Where("order_ginee_id = ?", orderGineeID)
+	default:
+		return nil, nil
+	}
+
+	var order Order
+	if err := query.First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// TrackingLookup is everything the stations recorded about a tracking number
+type TrackingLookup struct {
+	Tracking  string
+	Order     *Order
+	PickOrder *PickOrder
+	QcOnline  *QcOnline
+	QcRibbon  *QcRibbon
+	Outbound  *Outbound
+	Returns   []Return
+	Complains []Complain
+}
+
+// LookupTracking collects the order and the records of every station for a tracking number. The tracking number may
+// also be the new tracking number of a return, in which case the original order and its records are found through
+// the return. It returns gorm.ErrRecordNotFound when no station knows the tracking number.
+func LookupTracking(db *gorm.DB, tracking string) (*TrackingLookup, error) {
+	lookup := &TrackingLookup{Tracking: tracking}
+
+	var err error
+	if lookup.Order, err = ResolveOrder(db, tracking, ""); err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	// A returned parcel comes back with a new tracking number, the return links it to the original order
+	if lookup.Order == nil {
+		var ret Return
+		if err := db.Where("new_tracking = ?", tracking).Order("id DESC").First(&ret).Error; err == nil {
+			if lookup.Order, err = ResolveOrder(db, ret.OldTracking, ret.OrderGineeID); err != nil && err != gorm.ErrRecordNotFound {
+				return nil, err
+			}
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+	}
+
+	// Station records of the original parcel are kept under the tracking number of the order
+	parcelTracking := tracking
+	returnQuery := db.Where("new_tracking = ? OR old_tracking = ?", tracking, tracking)
+	complainQuery := db.Where("tracking = ?", tracking)
+	if lookup.Order != nil {
+		parcelTracking = lookup.Order.Tracking
+		returnQuery = db.Where("new_tracking = ? OR old_tracking = ? OR order_ginee_id = ?", tracking, parcelTracking, lookup.Order.OrderGineeID)
+		complainQuery = db.Where("tracking = ? OR order_ginee_id = ?", parcelTracking, lookup.Order.OrderGineeID)
+
+		var pickOrder PickOrder
+		if err := db.Where("order_id = ?", lookup.Order.ID).
+			Preload("PickOrderDetails").
+			Preload("Picker").
+			Order("id DESC").
+			First(&pickOrder).Error; err == nil {
+			lookup.PickOrder = &pickOrder
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+	}
+
+	var qcOnline QcOnline
+	if err := db.Where("tracking = ?", parcelTracking).Preload("QcOnlineDetails.Box").Preload("User").First(&qcOnline).Error; err == nil {
+		lookup.QcOnline = &qcOnline
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var qcRibbon QcRibbon
+	if err := db.Where("tracking = ?", parcelTracking).Preload("QcRibbonDetails.Box").Preload("User").First(&qcRibbon).Error; err == nil {
+		lookup.QcRibbon = &qcRibbon
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var outbound Outbound
+	if err := db.Where("tracking = ?", parcelTracking).Preload("User").First(&outbound).Error; err == nil {
+		lookup.Outbound = &outbound
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	if err := returnQuery.
+		Preload("ReturnDetails.Product").
+		Preload("Channel").
+		Preload("Store").
+		Order("id DESC").
+		Find(&lookup.Returns).Error; err != nil {
+		return nil, err
+	}
+
+	if err := complainQuery.
+		Preload("ProductDetails.Product").
+		Preload("UserDetails.User").
+		Preload("Channel").
+		Preload("Store").
+		Preload("Creator").
+		Order("id DESC").
+		Find(&lookup.Complains).Error; err != nil {
+		return nil, err
+	}
+
+	if lookup.Order == nil && lookup.QcOnline == nil && lookup.QcRibbon == nil && lookup.Outbound == nil &&
+		len(lookup.Returns) == 0 && len(lookup.Complains) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return lookup, nil
+}
+
+// CurrentStation returns the station the parcel was last handled at, from the furthest station that has a record of
+// it. A cancelled order stays with the admin whatever station it reached. It is empty when only complains are known.
+func (l *TrackingLookup) CurrentStation() string {
+	switch {
+	case len(l.Returns) > 0:
+		return OrderStationReturn
+	case l.Order != nil && l.Order.Status == OrderStatusCancelled:
+		return OrderStationAdmin
+	case l.Outbound != nil && l.Outbound.ManifestID != nil:
+		return OrderStationManifest
+	case l.Outbound != nil:
+		return OrderStationOutbound
+	case l.QcOnline != nil || l.QcRibbon != nil:
+		return OrderStationQC
+	case l.Order != nil:
+		return orderStatusStations[l.Order.Status]
+	}
+	return ""
+}
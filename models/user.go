@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"livo-backend-2.0/utils"
+
 	"gorm.io/gorm"
 )
 
@@ -53,7 +55,7 @@ func (u *User) ToUserResponse() UserResponse {
 			Role:        ur.Role.Role,
 			Description: ur.Role.Description,
 			AssignedBy:  ur.Assigner.Username,
-			AssignedAt:  ur.CreatedAt.Format("2006-01-02 15:04:05"),
+			AssignedAt:  ur.CreatedAt.In(utils.BusinessLocation()).Format("2006-01-02 15:04:05"),
 		}
 	}
 
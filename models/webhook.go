@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook order event types
+const (
+	WebhookEventOrderCreated   = "order.created"
+	WebhookEventOrderUpdated   = "order.updated"
+	WebhookEventOrderCancelled = "order.cancelled"
+)
+
+// Webhook event statuses
+const (
+	WebhookStatusReceived  = "received"
+	WebhookStatusProcessed = "processed"
+	WebhookStatusIgnored   = "ignored"
+	WebhookStatusDuplicate = "duplicate"
+	WebhookStatusFailed    = "failed"
+)
+
+// WebhookIntegration is a marketplace connection allowed to push orders. Its payloads are signed with Secret.
+type WebhookIntegration struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Code      string         `gorm:"unique;not null" json:"code"`
+	Name      string         `gorm:"not null" json:"name"`
+	Secret    string         `gorm:"not null" json:"-"`
+	Active    bool           `gorm:"not null;default:true" json:"active"`
+	CreatorID uint           `gorm:"not null" json:"creator_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Associations
+	Creator *User `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+}
+
+// WebhookEvent is a received webhook payload, kept as received so it can be replayed
+type WebhookEvent struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	IntegrationID uint       `gorm:"not null;index" json:"integration_id"`
+	EventID       string     `gorm:"default:null;index" json:"event_id"`
+	EventType     string     `gorm:"default:null;index" json:"event_type"`
+	OrderGineeID  string     `gorm:"default:null;index" json:"order_ginee_id"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"`
+	Status        string     `gorm:"not null;index" json:"status"`
+	Result        string     `gorm:"default:null" json:"result"`
+	OrderID       *uint      `gorm:"default:null;index" json:"order_id"`
+	Replays       int        `gorm:"not null;default:0" json:"replays"`
+	ProcessedAt   *time.Time `gorm:"default:null" json:"processed_at"`
+	CreatedAt     time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Associations
+	Integration *WebhookIntegration `gorm:"foreignKey:IntegrationID" json:"integration,omitempty"`
+}
+
+type WebhookIntegrationResponse struct {
+	ID        uint          `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Active    bool          `json:"active"`
+	CreatorID uint          `json:"creator_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Creator   *UserResponse `json:"creator,omitempty"`
+}
+
+type WebhookEventResponse struct {
+	ID              uint            `json:"id"`
+	IntegrationID   uint            `json:"integration_id"`
+	IntegrationCode string          `json:"integration_code"`
+	EventID         string          `json:"event_id"`
+	EventType       string          `json:"event_type"`
+	OrderGineeID    string          `json:"order_ginee_id"`
+	Status          string          `json:"status"`
+	Result          string          `json:"result"`
+	OrderID         *uint           `json:"order_id"`
+	Replays         int             `json:"replays"`
+	ProcessedAt     *time.Time      `json:"processed_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Payload         json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+}
+
+// ToWebhookIntegrationResponse converts WebhookIntegration model to WebhookIntegrationResponse
+func (wi *WebhookIntegration) ToWebhookIntegrationResponse() WebhookIntegrationResponse {
+	response := WebhookIntegrationResponse{
+		ID:        wi.ID,
+		Code:      wi.Code,
+		Name:      wi.Name,
+		Active:    wi.Active,
+		CreatorID: wi.CreatorID,
+		CreatedAt: wi.CreatedAt,
+		UpdatedAt: wi.UpdatedAt,
+	}
+
+	// Include creator data if loaded
+	if wi.Creator != nil {
+		creatorResp := wi.Creator.ToUserResponse()
+		response.Creator = &creatorResp
+	}
+
+	return response
+}
+
+// ToWebhookEventResponse converts WebhookEvent model to WebhookEventResponse. The payload is only included on request.
+func (we *WebhookEvent) ToWebhookEventResponse(includePayload bool) WebhookEventResponse {
+	response := WebhookEventResponse{
+		ID:            we.ID,
+		IntegrationID: we.IntegrationID,
+		EventID:       we.EventID,
+		EventType:     we.EventType,
+		OrderGineeID:  we.OrderGineeID,
+		Status:        we.Status,
+		Result:        we.Result,
+		OrderID:       we.OrderID,
+		Replays:       we.Replays,
+		ProcessedAt:   we.ProcessedAt,
+		CreatedAt:     we.CreatedAt,
+	}
+
+	// Include integration code if loaded
+	if we.Integration != nil {
+		response.IntegrationCode = we.Integration.Code
+	}
+
+	// Payloads are stored as received, so they are only embedded when they are valid JSON
+	if includePayload {
+		if json.Valid([]byte(we.Payload)) {
+			response.Payload = json.RawMessage(we.Payload)
+		} else {
+			quoted, _ := json.Marshal(we.Payload)
+			response.Payload = quoted
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAuditLogRoutes configures audit log routes
+func SetupAuditLogRoutes(api *gin.RouterGroup, cfg *config.Config, auditLogController *controllers.AuditLogController) {
+	// Audit log routes (superadmin only)
+	auditLog := api.Group("/audit-logs")
+	auditLog.Use(middleware.AuthMiddleware(cfg), middleware.RequiredSuperadminRole())
+	{
+		auditLog.GET("", auditLogController.GetAuditLogs) // Get audit logs of master data and user changes
+	}
+}
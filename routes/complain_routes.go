@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupComplainRoutes configures complain-related routes
+func SetupComplainRoutes(api *gin.RouterGroup, cfg *config.Config, complainController *controllers.ComplainController) {
+	// Complain routes (authenticated)
+	complain := api.Group("/complains")
+	complain.Use(middleware.AuthMiddleware(cfg))
+	{
+		// Public complain routes
+		complain.GET("", complainController.GetComplains)    // Get all complains (with optional search, status and date filtering)
+		complain.GET("/:id", complainController.GetComplain) // Get complain by ID
+
+		// Admin complain routes
+		complain.POST("", middleware.RequireAdminRole(), complainController.CreateComplain)         // Create complain and mark tracking as complained
+		complain.PUT("/:id", middleware.RequireAdminRole(), complainController.UpdateComplain)      // Update unsolved complain
+		complain.PUT("/:id/check", middleware.RequireAdminRole(), complainController.CheckComplain) // Mark complain as checked
+		complain.PUT("/:id/solve", middleware.RequireAdminRole(), complainController.SolveComplain) // Solve complain and clear complained flags
+		complain.DELETE("/:id", middleware.RequireAdminRole(), complainController.DeleteComplain)   // Delete complain
+	}
+}
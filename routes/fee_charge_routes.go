@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupFeeChargeRoutes configures operator fee charge routes
+func SetupFeeChargeRoutes(api *gin.RouterGroup, cfg *config.Config, feeChargeController *controllers.FeeChargeController) {
+	// Fee charge routes (authenticated + finance role)
+	feeCharge := api.Group("/fee-charges")
+	feeCharge.Use(middleware.AuthMiddleware(cfg), middleware.RequireFinanceRole())
+	{
+		feeCharge.GET("", feeChargeController.GetFeeCharges)                        // Get fee charges of a pay period
+		feeCharge.GET("/ledger", feeChargeController.GetFeeChargeLedger)            // Get totals per operator with reconciliation
+		feeCharge.POST("/ledger/export", feeChargeController.ExportFeeChargeLedger) // Queue payroll deductions export (csv)
+		feeCharge.PUT("/:id/approve", feeChargeController.ApproveFeeCharge)         // Approve fee charge for deduction
+		feeCharge.PUT("/:id/waive", feeChargeController.WaiveFeeCharge)             // Waive fee charge with a reason
+	}
+}
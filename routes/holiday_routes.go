@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupHolidayRoutes configures warehouse calendar and holiday routes
+func SetupHolidayRoutes(api *gin.RouterGroup, cfg *config.Config, holidayController *controllers.HolidayController) {
+	// Holiday routes (authenticated)
+	holiday := api.Group("/holidays")
+	holiday.Use(middleware.AuthMiddleware(cfg))
+	{
+		holiday.GET("", holidayController.GetHolidays)                                         // Get holidays (with optional year filtering)
+		holiday.GET("/calendar", holidayController.GetBusinessCalendar)                        // Get timezone, working days and working hours
+		holiday.POST("", middleware.RequireAdminRole(), holidayController.CreateHoliday)       // Create new holiday
+		holiday.PUT("/:id", middleware.RequireAdminRole(), holidayController.UpdateHoliday)    // Update holiday by ID
+		holiday.DELETE("/:id", middleware.RequireAdminRole(), holidayController.RemoveHoliday) // Delete holiday by ID
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupJobRoutes configures background job routes
+func SetupJobRoutes(api *gin.RouterGroup, cfg *config.Config, jobController *controllers.JobController) {
+	// Job routes (authenticated, users see their own jobs)
+	job := api.Group("/jobs")
+	job.Use(middleware.AuthMiddleware(cfg))
+	{
+		job.GET("", jobController.GetJobs)                      // Get background jobs
+		job.GET("/:id", jobController.GetJob)                   // Get job status, progress and result
+		job.GET("/:id/download", jobController.DownloadJobFile) // Download file produced by the job
+		job.PUT("/:id/cancel", jobController.CancelJob)         // Cancel queued job or stop running job
+		job.PUT("/:id/retry", jobController.RetryJob)           // Queue failed or cancelled job again
+	}
+}
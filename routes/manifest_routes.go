@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupManifestRoutes configures courier handover manifest routes
+func SetupManifestRoutes(api *gin.RouterGroup, cfg *config.Config, manifestController *controllers.ManifestController) {
+	// Manifest routes (authenticated + outbound role)
+	manifest := api.Group("/manifests")
+	manifest.Use(middleware.AuthMiddleware(cfg), middleware.RequireOutboundRole())
+	{
+		manifest.POST("", manifestController.OpenManifest)                   // Open manifest for an expedition
+		manifest.GET("", manifestController.GetManifests)                    // Get all manifests (with optional status, expedition and date filtering)
+		manifest.GET("/summary", manifestController.GetManifestSummary)      // Get pending parcels and open manifest per expedition
+		manifest.GET("/:id", manifestController.GetManifest)                 // Get manifest with parcels
+		manifest.PUT("/:id/close", manifestController.CloseManifest)         // Close manifest on courier pickup
+		manifest.GET("/:id/export", manifestController.ExportManifest)       // Download handover document (pdf)
+		manifest.POST("/:id/export", manifestController.QueueManifestExport) // Queue parcel list export (csv)
+	}
+}
@@ -16,11 +16,21 @@ func SetupOrderRoutes(api *gin.RouterGroup, cfg *config.Config, orderController
 	{
 		// Public order routes
 		order.GET("", orderController.GetOrders)                                  // Get all orders (with optional search and date filtering)
+		order.GET("/sla", orderController.GetOrderSla)                            // Get open orders per SLA bucket, store and courier
+		order.GET("/priority-queue", orderController.GetPriorityQueue)            // Get ready to pick orders by processing limit
 		order.GET("/:id", orderController.GetOrder)                               // Get specific order by ID (full details)
+		order.GET("/:id/timeline", orderController.GetOrderTimeline)              // Get order history and station journey
 		order.POST("", orderController.CreateOrder)                               // Create new order
-		order.POST("/bulk", orderController.BulkCreateOrders)                     // Create multiple orders
+		order.POST("/bulk", orderController.BulkCreateOrders)                     // Queue creation of multiple orders
+		order.POST("/import/preview", orderController.PreviewGineeImport)         // Preview orders parsed from a Ginee export file
+		order.POST("/import", orderController.ImportGineeOrders)                  // Queue creation of orders from a Ginee export file
 		order.PUT("/:id/complained", orderController.UpdateOrderComplainedStatus) // Update complained status
 
+		// Order lifecycle routes
+		order.GET("/:id/status", orderController.GetOrderStatusTransitions)                                   // Get current status and allowed next statuses
+		order.PUT("/:id/status", middleware.RequireOrderManagementRoles(), orderController.UpdateOrderStatus) // Send order back to ready to pick
+		order.PUT("/:id/cancel", middleware.RequireAdminRole(), orderController.CancelOrder)                  // Cancel order with a reason
+
 		// Public order details route
 		order.GET("/:id/details", orderController.GetOrderDetails)                 // Get order details
 		order.POST("/:id/details", orderController.AddOrderDetail)                 // Add new order detail to an order
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOutboundRoutes configures outbound station routes
+func SetupOutboundRoutes(api *gin.RouterGroup, cfg *config.Config, outboundController *controllers.OutboundController) {
+	// Outbound routes (authenticated + outbound role)
+	outbound := api.Group("/outbounds")
+	outbound.Use(middleware.AuthMiddleware(cfg), middleware.RequireOutboundRole())
+	{
+		outbound.POST("", outboundController.CreateOutbound) // Scan tracking and detect expedition
+		outbound.GET("", outboundController.GetOutbounds)    // Get all outbounds (with optional search, expedition and date filtering)
+		outbound.GET("/:id", outboundController.GetOutbound) // Get outbound by ID
+	}
+}
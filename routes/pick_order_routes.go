@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPickOrderRoutes configures picking-related routes
+func SetupPickOrderRoutes(api *gin.RouterGroup, cfg *config.Config, pickOrderController *controllers.PickOrderController) {
+	// Pick order routes (authenticated + picker role)
+	pickOrder := api.Group("/pick-orders")
+	pickOrder.Use(middleware.AuthMiddleware(cfg), middleware.RequirePickerRole())
+	{
+		pickOrder.POST("/claim", pickOrderController.ClaimOrder)               // Claim order by tracking or order ginee ID
+		pickOrder.GET("/mine", pickOrderController.GetMyPickOrders)            // Get pick history of current picker
+		pickOrder.GET("/:id", pickOrderController.GetPickOrder)                // Get pick order with progress
+		pickOrder.POST("/:id/scan", pickOrderController.ScanPickItem)          // Scan item barcode
+		pickOrder.PUT("/:id/items", pickOrderController.SubmitPickedItems)     // Record picked items
+		pickOrder.POST("/:id/complete", pickOrderController.CompletePickOrder) // Complete pick order
+		pickOrder.POST("/:id/release", pickOrderController.ReleasePickOrder)   // Release claimed order
+	}
+}
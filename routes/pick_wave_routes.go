@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPickWaveRoutes configures batch picking routes
+func SetupPickWaveRoutes(api *gin.RouterGroup, cfg *config.Config, pickWaveController *controllers.PickWaveController) {
+	// Pick wave routes (authenticated + picker role)
+	pickWave := api.Group("/pick-waves")
+	pickWave.Use(middleware.AuthMiddleware(cfg), middleware.RequirePickerRole())
+	{
+		pickWave.POST("", pickWaveController.CreatePickWave)                      // Create wave from ready to pick orders
+		pickWave.GET("", pickWaveController.GetMyPickWaves)                       // Get waves of current picker
+		pickWave.GET("/:id", pickWaveController.GetPickWave)                      // Get wave with pick list
+		pickWave.GET("/:id/pick-list", pickWaveController.GetPickList)            // Get consolidated pick list
+		pickWave.POST("/:id/pick-list/export", pickWaveController.ExportPickList) // Queue printable pick list export (csv)
+		pickWave.POST("/:id/complete", pickWaveController.CompletePickWave)       // Distribute picked items and complete wave
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupProductRoutes configures product-related routes
+func SetupProductRoutes(api *gin.RouterGroup, cfg *config.Config, productController *controllers.ProductController) {
+	// Product routes (authenticated)
+	product := api.Group("/products")
+	product.Use(middleware.AuthMiddleware(cfg))
+	{
+		// Public product routes
+		product.GET("", productController.GetProducts)                 // Get all products (with optional search)
+		product.GET("/code/:code", productController.GetProductByCode) // Get product by barcode or SKU
+		product.GET("/:id", productController.GetProduct)              // Get product by ID
+
+		// Product management routes
+		product.POST("", middleware.RequireProductManagementRoles(), productController.CreateProduct)              // Create new product
+		product.POST("/import", middleware.RequireProductManagementRoles(), productController.ImportProducts)      // Queue product upsert from csv/xlsx file
+		product.PUT("/:id", middleware.RequireProductManagementRoles(), productController.UpdateProduct)           // Update product by ID
+		product.PUT("/:id/bundle", middleware.RequireProductManagementRoles(), productController.SetProductBundle) // Set bundle components of product
+		product.DELETE("/:id", middleware.RequireProductManagementRoles(), productController.RemoveProduct)        // Delete product by ID
+	}
+}
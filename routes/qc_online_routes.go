@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupQcOnlineRoutes configures QC online station routes
+func SetupQcOnlineRoutes(api *gin.RouterGroup, cfg *config.Config, qcOnlineController *controllers.QcOnlineController) {
+	// QC online routes (authenticated + qc-online role)
+	qcOnline := api.Group("/qc-online")
+	qcOnline.Use(middleware.AuthMiddleware(cfg), middleware.RequireQCOnlineRole())
+	{
+		qcOnline.POST("", qcOnlineController.CreateQcOnline)                  // Scan tracking and record boxes
+		qcOnline.GET("", qcOnlineController.GetQcOnlines)                     // Get all QC online (with optional search and date filtering)
+		qcOnline.GET("/queue", qcOnlineController.GetQcOnlineQueue)           // Get picked online orders waiting for QC
+		qcOnline.GET("/statistics", qcOnlineController.GetQcOnlineStatistics) // Get QC statistics per online store
+		qcOnline.GET("/:id", qcOnlineController.GetQcOnline)                  // Get QC online by ID
+		qcOnline.PUT("/:id", qcOnlineController.UpdateQcOnline)               // Replace recorded boxes
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupQcRibbonRoutes configures QC ribbon station routes
+func SetupQcRibbonRoutes(api *gin.RouterGroup, cfg *config.Config, qcRibbonController *controllers.QcRibbonController) {
+	// QC ribbon routes (authenticated + qc-ribbon role)
+	qcRibbon := api.Group("/qc-ribbon")
+	qcRibbon.Use(middleware.AuthMiddleware(cfg), middleware.RequireQCRibbonRole())
+	{
+		qcRibbon.POST("", qcRibbonController.CreateQcRibbon)                  // Scan tracking and record boxes
+		qcRibbon.GET("", qcRibbonController.GetQcRibbons)                     // Get all QC ribbon (with optional search and date filtering)
+		qcRibbon.GET("/queue", qcRibbonController.GetQcRibbonQueue)           // Get picked ribbon orders waiting for QC
+		qcRibbon.GET("/statistics", qcRibbonController.GetQcRibbonStatistics) // Get QC statistics per ribbon store
+		qcRibbon.GET("/:id", qcRibbonController.GetQcRibbon)                  // Get QC ribbon by ID
+		qcRibbon.PUT("/:id", qcRibbonController.UpdateQcRibbon)               // Replace recorded boxes
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupReturnRoutes configures return intake routes
+func SetupReturnRoutes(api *gin.RouterGroup, cfg *config.Config, returnController *controllers.ReturnController) {
+	// Return routes (authenticated + return role)
+	ret := api.Group("/returns")
+	ret.Use(middleware.AuthMiddleware(cfg), middleware.RequireReturnRole())
+	{
+		ret.GET("/lookup", returnController.LookupReturnOrder)   // Look up original order by old tracking or order ginee ID
+		ret.POST("/mobile", returnController.CreateMobileReturn) // Receive returned parcel by new tracking
+		ret.POST("", returnController.CreateReturn)              // Create return with received products
+		ret.GET("", returnController.GetReturns)                 // Get all returns (with optional search, processed and date filtering)
+		ret.GET("/:id", returnController.GetReturn)              // Get return by ID
+		ret.PUT("/:id", returnController.UpdateReturn)           // Complete or correct unprocessed return
+		ret.PUT("/:id/process", returnController.ProcessReturn)  // Finalize restock/scrap outcomes and generate numbers
+		ret.DELETE("/:id", returnController.DeleteReturn)        // Delete unprocessed return
+	}
+}
@@ -3,10 +3,10 @@ package routes
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"livo-backend-2.0/config"
 	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/utils"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -15,7 +15,7 @@ import (
 )
 
 // SetupRoutes configures all routes for the application
-func SetupRoutes(cfg *config.Config, authController *controllers.AuthController, userManagerController *controllers.UserManagerController, boxController *controllers.BoxController, channelController *controllers.ChannelController, expeditionController *controllers.ExpeditionController, storeController *controllers.StoreController, orderController *controllers.OrderController) *gin.Engine {
+func SetupRoutes(cfg *config.Config, authController *controllers.AuthController, userManagerController *controllers.UserManagerController, boxController *controllers.BoxController, channelController *controllers.ChannelController, expeditionController *controllers.ExpeditionController, storeController *controllers.StoreController, orderController *controllers.OrderController, pickOrderController *controllers.PickOrderController, pickWaveController *controllers.PickWaveController, qcOnlineController *controllers.QcOnlineController, qcRibbonController *controllers.QcRibbonController, outboundController *controllers.OutboundController, manifestController *controllers.ManifestController, complainController *controllers.ComplainController, feeChargeController *controllers.FeeChargeController, returnController *controllers.ReturnController, productController *controllers.ProductController, stockController *controllers.StockController, stockOpnameController *controllers.StockOpnameController, webhookController *controllers.WebhookController, jobController *controllers.JobController, holidayController *controllers.HolidayController, auditLogController *controllers.AuditLogController, trackingController *controllers.TrackingController) *gin.Engine {
 	// Set Gin mode
 	gin.SetMode(cfg.GinMode)
 
@@ -157,7 +157,7 @@ func SetupRoutes(cfg *config.Config, authController *controllers.AuthController,
 		c.JSON(200, gin.H{
 			"status":    "ok",
 			"message":   "Livotech Backend Service is running smoothly.",
-			"timestamp": time.Now().Format("02 January 2006 - 15:04:05"),
+			"timestamp": utils.BusinessNow().Format("02 January 2006 - 15:04:05"),
 		})
 	})
 
@@ -172,6 +172,23 @@ func SetupRoutes(cfg *config.Config, authController *controllers.AuthController,
 	SetupExpeditionRoutes(api, cfg, expeditionController)
 	SetupStoreRoutes(api, cfg, storeController)
 	SetupOrderRoutes(api, cfg, orderController)
+	SetupPickOrderRoutes(api, cfg, pickOrderController)
+	SetupPickWaveRoutes(api, cfg, pickWaveController)
+	SetupQcOnlineRoutes(api, cfg, qcOnlineController)
+	SetupQcRibbonRoutes(api, cfg, qcRibbonController)
+	SetupOutboundRoutes(api, cfg, outboundController)
+	SetupManifestRoutes(api, cfg, manifestController)
+	SetupComplainRoutes(api, cfg, complainController)
+	SetupFeeChargeRoutes(api, cfg, feeChargeController)
+	SetupReturnRoutes(api, cfg, returnController)
+	SetupProductRoutes(api, cfg, productController)
+	SetupStockRoutes(api, cfg, stockController)
+	SetupStockOpnameRoutes(api, cfg, stockOpnameController)
+	SetupWebhookRoutes(api, cfg, webhookController)
+	SetupJobRoutes(api, cfg, jobController)
+	SetupHolidayRoutes(api, cfg, holidayController)
+	SetupAuditLogRoutes(api, cfg, auditLogController)
+	SetupTrackingRoutes(api, cfg, trackingController)
 
 	return router
 }
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupStockOpnameRoutes configures stock opname (cycle count) routes
+func SetupStockOpnameRoutes(api *gin.RouterGroup, cfg *config.Config, stockOpnameController *controllers.StockOpnameController) {
+	// Stock opname routes (authenticated)
+	opname := api.Group("/stock-opnames")
+	opname.Use(middleware.AuthMiddleware(cfg))
+	{
+		// Counting routes
+		opname.GET("", middleware.RequireStockCountRole(), stockOpnameController.GetStockOpnames)                              // Get all stock opnames (with optional status and date filtering)
+		opname.GET("/:id", middleware.RequireStockCountRole(), stockOpnameController.GetStockOpname)                           // Get stock opname with lines
+		opname.POST("/:id/scans", middleware.RequireStockCountRole(), stockOpnameController.ScanStockOpname)                   // Add scanned item to its line count
+		opname.PUT("/:id/lines/:lineId/count", middleware.RequireStockCountRole(), stockOpnameController.CountStockOpnameLine) // Set line count directly
+
+		// Approval routes
+		opname.POST("", middleware.RequireStockApprovalRole(), stockOpnameController.CreateStockOpname)                               // Start count session for locations and/or SKUs
+		opname.PUT("/:id/lines/:lineId/approve", middleware.RequireStockApprovalRole(), stockOpnameController.ApproveStockOpnameLine) // Approve line and book its variance
+		opname.PUT("/:id/lines/:lineId/recount", middleware.RequireStockApprovalRole(), stockOpnameController.RecountStockOpnameLine) // Clear line count for a recount
+		opname.PUT("/:id/approve", middleware.RequireStockApprovalRole(), stockOpnameController.ApproveStockOpname)                   // Approve all counted lines
+		opname.PUT("/:id/cancel", middleware.RequireStockApprovalRole(), stockOpnameController.CancelStockOpname)                     // Cancel counting session
+		opname.POST("/:id/export", middleware.RequireStockApprovalRole(), stockOpnameController.ExportStockOpname)                    // Queue variance report export (csv)
+	}
+}
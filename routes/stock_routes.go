@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupStockRoutes configures stock-related routes
+func SetupStockRoutes(api *gin.RouterGroup, cfg *config.Config, stockController *controllers.StockController) {
+	// Stock routes (authenticated)
+	stock := api.Group("/stocks")
+	stock.Use(middleware.AuthMiddleware(cfg))
+	{
+		// Public stock routes
+		stock.GET("", stockController.GetStocks)                        // Get stock on hand per SKU and bin
+		stock.GET("/:sku", stockController.GetSkuStock)                 // Get stock of a SKU in every bin
+		stock.GET("/:sku/movements", stockController.GetStockMovements) // Get movement history of a SKU
+
+		// Stock management routes
+		stock.POST("/movements", middleware.RequireProductManagementRoles(), stockController.CreateStockMovement) // Record receipt, scrap or adjustment
+	}
+}
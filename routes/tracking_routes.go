@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTrackingRoutes configures tracking lookup routes
+func SetupTrackingRoutes(api *gin.RouterGroup, cfg *config.Config, trackingController *controllers.TrackingController) {
+	// Tracking routes (authenticated)
+	track := api.Group("/track")
+	track.Use(middleware.AuthMiddleware(cfg))
+	{
+		track.GET("/:tracking", trackingController.TrackParcel) // Look up a tracking number across all stations
+	}
+}
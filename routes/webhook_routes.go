@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"livo-backend-2.0/config"
+	"livo-backend-2.0/controllers"
+	"livo-backend-2.0/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWebhookRoutes configures webhook-related routes
+func SetupWebhookRoutes(api *gin.RouterGroup, cfg *config.Config, webhookController *controllers.WebhookController) {
+	// Webhook receiver routes (authenticated by the payload signature)
+	webhook := api.Group("/webhooks")
+	{
+		webhook.POST("/orders/:code", webhookController.ReceiveOrderWebhook) // Receive order created/updated/cancelled events
+	}
+
+	// Webhook integration routes (authenticated)
+	integration := api.Group("/webhook-integrations")
+	integration.Use(middleware.AuthMiddleware(cfg), middleware.RequiredSuperadminRole())
+	{
+		integration.GET("", webhookController.GetWebhookIntegrations)                           // Get all integrations
+		integration.POST("", webhookController.CreateWebhookIntegration)                        // Create integration with a signing secret
+		integration.PUT("/:id", webhookController.UpdateWebhookIntegration)                     // Rename or enable/disable integration
+		integration.PUT("/:id/rotate-secret", webhookController.RotateWebhookIntegrationSecret) // Generate a new signing secret
+	}
+
+	// Webhook event routes (authenticated)
+	event := api.Group("/webhook-events")
+	event.Use(middleware.AuthMiddleware(cfg), middleware.RequiredSuperadminRole())
+	{
+		event.GET("", webhookController.GetWebhookEvents)              // Get received payloads
+		event.GET("/:id", webhookController.GetWebhookEvent)           // Get received payload
+		event.PUT("/:id/replay", webhookController.ReplayWebhookEvent) // Process received payload again
+	}
+}
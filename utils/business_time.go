@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BusinessDateLayout is the format of a business day, as used by date filters and holidays
+const BusinessDateLayout = "2006-01-02"
+
+// maxCalendarDays bounds the days walked through when adding working time, so a calendar without working days
+// cannot loop forever
+const maxCalendarDays = 366
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Calendar is the working calendar of the warehouse: its timezone, working days, daily working hours and holidays.
+// A calendar is never modified once in use; changes are made on a copy that replaces the business calendar.
+type Calendar struct {
+	Location    *time.Location
+	WorkingDays map[time.Weekday]bool
+	OpenAt      time.Duration // Start of the working hours, from midnight
+	CloseAt     time.Duration // End of the working hours, from midnight
+	Holidays    map[string]bool
+}
+
+var (
+	calendarMu       sync.RWMutex
+	businessCalendar = &Calendar{
+		// Until it is configured every hour of every day in UTC is working time
+		Location: time.UTC,
+		WorkingDays: map[time.Weekday]bool{
+			time.Sunday: true, time.Monday: true, time.Tuesday: true, time.Wednesday: true,
+			time.Thursday: true, time.Friday: true, time.Saturday: true,
+		},
+		OpenAt:   0,
+		CloseAt:  24 * time.Hour,
+		Holidays: map[string]bool{},
+	}
+)
+
+// NewCalendar builds a calendar from the timezone name (e.g. Asia/Jakarta), the working days as comma separated
+// weekdays (e.g. mon,tue,wed,thu,fri,sat) and the working hours as HH:MM-HH:MM (e.g. 08:00-17:00)
+func NewCalendar(timezone, workingDays, workingHours string) (*Calendar, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %s", timezone, err.Error())
+	}
+
+	days, err := parseWorkingDays(workingDays)
+	if err != nil {
+		return nil, err
+	}
+
+	openAt, closeAt, err := parseWorkingHours(workingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Calendar{
+		Location:    location,
+		WorkingDays: days,
+		OpenAt:      openAt,
+		CloseAt:     closeAt,
+		Holidays:    map[string]bool{},
+	}, nil
+}
+
+// parseWorkingDays parses comma separated weekdays, e.g. mon,tue,wed
+func parseWorkingDays(value string) (map[time.Weekday]bool, error) {
+	days := map[time.Weekday]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid working day '%s': use sun, mon, tue, wed, thu, fri or sat", name)
+		}
+		days[day] = true
+	}
+
+	if len(days) == 0 {
+		return nil, fmt.Errorf("at least one working day is required")
+	}
+	return days, nil
+}
+
+// parseWorkingHours parses working hours in HH:MM-HH:MM format. The end may be 24:00.
+func parseWorkingHours(value string) (time.Duration, time.Duration, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid working hours '%s': use HH:MM-HH:MM format", value)
+	}
+
+	var bounds [2]time.Duration
+	for i, part := range parts {
+		var hours, minutes int
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d:%d", &hours, &minutes); err != nil ||
+			hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > 24*60 {
+			return 0, 0, fmt.Errorf("invalid working hours '%s': use HH:MM-HH:MM format", value)
+		}
+		bounds[i] = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	}
+
+	if bounds[0] >= bounds[1] {
+		return 0, 0, fmt.Errorf("invalid working hours '%s': start must be before end", value)
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// WithHolidays returns a copy of the calendar with the given holidays in YYYY-MM-DD format
+func (cal *Calendar) WithHolidays(dates []string) *Calendar {
+	holidays := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		holidays[date] = true
+	}
+
+	updated := *cal
+	updated.Holidays = holidays
+	return &updated
+}
+
+// IsHoliday checks whether t falls on a holiday
+func (cal *Calendar) IsHoliday(t time.Time) bool {
+	return cal.Holidays[t.In(cal.Location).Format(BusinessDateLayout)]
+}
+
+// IsWorkingDay checks whether t falls on a working day that is not a holiday
+func (cal *Calendar) IsWorkingDay(t time.Time) bool {
+	return cal.WorkingDays[t.In(cal.Location).Weekday()] && !cal.IsHoliday(t)
+}
+
+// StartOfDay returns the midnight that starts the business day of t
+func (cal *Calendar) StartOfDay(t time.Time) time.Time {
+	t = t.In(cal.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, cal.Location)
+}
+
+// workingHours returns the working hours of the business day starting at day, false when it is not a working day
+func (cal *Calendar) workingHours(day time.Time) (time.Time, time.Time, bool) {
+	if !cal.IsWorkingDay(day) {
+		return time.Time{}, time.Time{}, false
+	}
+	return day.Add(cal.OpenAt), day.Add(cal.CloseAt), true
+}
+
+// WorkingDuration returns the working time between from and to, zero when to is not after from
+func (cal *Calendar) WorkingDuration(from, to time.Time) time.Duration {
+	var total time.Duration
+	for day := cal.StartOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end, ok := cal.workingHours(day)
+		if !ok {
+			continue
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// AddWorkingDuration returns the moment the given working time has passed from from, skipping the hours outside
+// working hours, the non working days and the holidays
+func (cal *Calendar) AddWorkingDuration(from time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return from
+	}
+
+	day := cal.StartOfDay(from)
+	for i := 0; i < maxCalendarDays; i++ {
+		start, end, ok := cal.workingHours(day)
+		if ok && end.After(from) {
+			if start.Before(from) {
+				start = from
+			}
+			available := end.Sub(start)
+			if d <= available {
+				return start.Add(d)
+			}
+			d -= available
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return from.Add(d)
+}
+
+// BusinessCalendar returns the working calendar of the warehouse
+func BusinessCalendar() *Calendar {
+	calendarMu.RLock()
+	defer calendarMu.RUnlock()
+	return businessCalendar
+}
+
+// SetBusinessCalendar replaces the working calendar of the warehouse
+func SetBusinessCalendar(cal *Calendar) {
+	calendarMu.Lock()
+	defer calendarMu.Unlock()
+	businessCalendar = cal
+}
+
+// SetBusinessHolidays replaces the holidays of the working calendar, given in YYYY-MM-DD format
+func SetBusinessHolidays(dates []string) {
+	calendarMu.Lock()
+	defer calendarMu.Unlock()
+	businessCalendar = businessCalendar.WithHolidays(dates)
+}
+
+// BusinessLocation returns the timezone of the warehouse. A day always means a day in this timezone.
+func BusinessLocation() *time.Location {
+	return BusinessCalendar().Location
+}
+
+// BusinessNow returns the current time in the timezone of the warehouse
+func BusinessNow() time.Time {
+	return time.Now().In(BusinessLocation())
+}
+
+// ParseBusinessDate parses a YYYY-MM-DD date as the midnight that starts that day in the timezone of the warehouse
+func ParseBusinessDate(value string) (time.Time, error) {
+	return time.ParseInLocation(BusinessDateLayout, value, BusinessLocation())
+}
+
+// ParseBusinessTime parses a date time without timezone as a time in the timezone of the warehouse
+func ParseBusinessTime(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, BusinessLocation())
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// testCalendar is a Monday to Friday 08:00-17:00 calendar in Asia/Jakarta with Independence Day 2026 (a Monday)
+// as holiday
+func testCalendar(t *testing.T) *Calendar {
+	t.Helper()
+	cal, err := NewCalendar("Asia/Jakarta", "mon,tue,wed,thu,fri", "08:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cal.WithHolidays([]string{"2026-08-17"})
+}
+
+func jakarta(t *testing.T, cal *Calendar, value string) time.Time {
+	t.Helper()
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, cal.Location)
+	if err != nil {
+		t.Fatalf("invalid time '%s': %v", value, err)
+	}
+	return parsed
+}
+
+func TestCalendarWorkingDuration(t *testing.T) {
+	cal := testCalendar(t)
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want time.Duration
+	}{
+		{name: "within working hours", from: "2026-08-12 09:00", to: "2026-08-12 11:15", want: 2*time.Hour + 15*time.Minute},
+		{name: "overnight", from: "2026-08-12 20:00", to: "2026-08-13 08:30", want: 30 * time.Minute},
+		{name: "before opening", from: "2026-08-12 06:00", to: "2026-08-12 07:59", want: 0},
+		{name: "whole day", from: "2026-08-12 00:00", to: "2026-08-13 00:00", want: 9 * time.Hour},
+		{name: "weekend", from: "2026-08-08 00:00", to: "2026-08-10 00:00", want: 0},
+		{name: "weekend and holiday", from: "2026-08-14 16:00", to: "2026-08-18 09:00", want: 2 * time.Hour},
+		{name: "to before from", from: "2026-08-12 11:00", to: "2026-08-12 09:00", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cal.WorkingDuration(jakarta(t, cal, tt.from), jakarta(t, cal, tt.to))
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarWorkingDurationUsesCalendarTimezone(t *testing.T) {
+	cal := testCalendar(t)
+
+	// 09:00 to 12:00 UTC is 16:00 to 19:00 in Jakarta, one hour before closing
+	from := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 8, 12, 12, 0, 0, 0, time.UTC)
+	if got := cal.WorkingDuration(from, to); got != time.Hour {
+		t.Errorf("got %v, want %v", got, time.Hour)
+	}
+}
+
+func TestCalendarAddWorkingDuration(t *testing.T) {
+	cal := testCalendar(t)
+
+	tests := []struct {
+		name     string
+		from     string
+		duration time.Duration
+		want     string
+	}{
+		{name: "within working hours", from: "2026-08-12 09:00", duration: 90 * time.Minute, want: "2026-08-12 10:30"},
+		{name: "ends at closing", from: "2026-08-12 08:00", duration: 9 * time.Hour, want: "2026-08-12 17:00"},
+		{name: "rolls over the night", from: "2026-08-12 16:00", duration: 2 * time.Hour, want: "2026-08-13 09:00"},
+		{name: "starts at night", from: "2026-08-12 20:00", duration: 30 * time.Minute, want: "2026-08-13 08:30"},
+		{name: "skips weekend and holiday", from: "2026-08-14 16:00", duration: 2 * time.Hour, want: "2026-08-18 09:00"},
+		{name: "starts on the weekend", from: "2026-08-15 10:00", duration: time.Hour, want: "2026-08-18 09:00"},
+		{name: "zero duration", from: "2026-08-15 10:00", duration: 0, want: "2026-08-15 10:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := jakarta(t, cal, tt.from)
+			got := cal.AddWorkingDuration(from, tt.duration)
+			if want := jakarta(t, cal, tt.want); !got.Equal(want) {
+				t.Errorf("got %v, want %v", got.In(cal.Location), want)
+			}
+			if tt.duration > 0 {
+				if back := cal.WorkingDuration(from, got); back != tt.duration {
+					t.Errorf("working duration back is %v, want %v", back, tt.duration)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarWithoutWorkingDaysStops(t *testing.T) {
+	cal := testCalendar(t)
+	cal.WorkingDays = map[time.Weekday]bool{}
+
+	from := jakarta(t, cal, "2026-08-12 09:00")
+	if got := cal.AddWorkingDuration(from, time.Hour); !got.Equal(from.Add(time.Hour)) {
+		t.Errorf("got %v, want %v", got, from.Add(time.Hour))
+	}
+}
+
+func TestNewCalendarRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		days     string
+		hours    string
+	}{
+		{name: "timezone", timezone: "Asia/Nowhere", days: "mon", hours: "08:00-17:00"},
+		{name: "day", timezone: "Asia/Jakarta", days: "mon,funday", hours: "08:00-17:00"},
+		{name: "no days", timezone: "Asia/Jakarta", days: " , ", hours: "08:00-17:00"},
+		{name: "hours format", timezone: "Asia/Jakarta", days: "mon", hours: "08:00"},
+		{name: "hours order", timezone: "Asia/Jakarta", days: "mon", hours: "17:00-08:00"},
+		{name: "hours range", timezone: "Asia/Jakarta", days: "mon", hours: "08:00-24:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCalendar(tt.timezone, tt.days, tt.hours); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// nextDailySequence returns the next number of a daily sequence and locks the sequence until the transaction commits
+// or rolls back, so concurrent requests never get the same number. key is the "table.column" holding the values of
+// the sequence and prefix the part every value of the day starts with. Soft deleted rows keep their value in the
+// unique index, so they are counted too.
+func nextDailySequence(tx *gorm.DB, key, prefix string) (int64, error) {
+	// Serialize the sequence for the day until the transaction commits or rolls back
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key+":"+prefix).Error; err != nil {
+		return 0, err
+	}
+
+	table, column, _ := strings.Cut(key, ".")
+	var count int64
+	if err := tx.Table(table).Where(column+" LIKE ?", prefix+"%").Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count + 1, nil
+}
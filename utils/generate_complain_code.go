@@ -3,16 +3,17 @@ package utils
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"gorm.io/gorm"
 )
 
 // GenerateComplainCode generates a complain code with format: YYYYMMDD + first 2 chars of username + 3-digit auto increment
 // Example: 20251008SA001, 20251008SA002, etc.
-func GenerateComplainCode(db *gorm.DB, username string) string {
-	// Get current date in YYYYMMDD format
-	now := time.Now()
+// It must be called inside the transaction that creates the complain: the daily sequence is locked until the transaction ends,
+// so concurrent requests never get the same number.
+func GenerateComplainCode(tx *gorm.DB, username string) (string, error) {
+	// Get current date in YYYYMMDD format, in the business timezone
+	now := BusinessNow()
 	datePrefix := now.Format("20060102")
 
 	// Get first 2 characters of username (uppercase)
@@ -25,19 +26,14 @@ func GenerateComplainCode(db *gorm.DB, username string) string {
 		userPrefix = "XX" // Default if username is empty
 	}
 
-	// Count complains for current date to get auto increment number
-	var count int64
-	startOfDay := now.Format("2006-01-02 00:00:00")
-	endOfDay := now.Format("2006-01-02 23:59:59")
-
-	// Import the models package or pass the table name
-	db.Table("complains").Where("created-at >= ? AND created_at <= ?", startOfDay, endOfDay).Count(&count)
-
-	// Increment count by 1 for the new record
-	autoIncrement := count + 1
+	// The sequence is shared by all users, so it counts every complain of the day
+	autoIncrement, err := nextDailySequence(tx, "complains.code", datePrefix)
+	if err != nil {
+		return "", err
+	}
 
 	// Format auto increment as 3-digit with leading zeros
 	complainCode := fmt.Sprintf("%s%s%03d", datePrefix, userPrefix, autoIncrement)
 
-	return complainCode
+	return complainCode, nil
 }
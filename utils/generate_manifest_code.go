@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GenerateManifestCode generates a manifest code with format: MF + YYYYMMDD + 3-digit auto increment
+// Example: MF20251008001, MF20251008002, etc.
+// It must be called inside the transaction that saves the code.
+func GenerateManifestCode(tx *gorm.DB) (string, error) {
+	codePrefix := "MF" + BusinessNow().Format("20060102")
+
+	next, err := nextDailySequence(tx, "manifests.code", codePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%03d", codePrefix, next), nil
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GenerateOpnameCode generates a stock opname code with format: SO + YYYYMMDD + 3-digit auto increment
+// Example: SO20251008001, SO20251008002, etc.
+// It must be called inside the transaction that saves the code.
+func GenerateOpnameCode(tx *gorm.DB) (string, error) {
+	codePrefix := "SO" + BusinessNow().Format("20060102")
+
+	next, err := nextDailySequence(tx, "stock_opnames.code", codePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%03d", codePrefix, next), nil
+}
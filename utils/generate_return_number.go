@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GenerateReturnNumber generates a return number for restocked returns with format: RT + YYYYMMDD + 4-digit auto increment
+// Example: RT202510080001, RT202510080002, etc.
+// It must be called inside the transaction that saves the number.
+func GenerateReturnNumber(tx *gorm.DB) (string, error) {
+	return generateDailyReturnSequence(tx, "RT", "return_number")
+}
+
+// GenerateScrapNumber generates a scrap number for scrapped returns with format: SC + YYYYMMDD + 4-digit auto increment
+// Example: SC202510080001, SC202510080002, etc.
+// It must be called inside the transaction that saves the number.
+func GenerateScrapNumber(tx *gorm.DB) (string, error) {
+	return generateDailyReturnSequence(tx, "SC", "scrap_number")
+}
+
+// generateDailyReturnSequence returns the next number of the daily sequence of the column
+func generateDailyReturnSequence(tx *gorm.DB, prefix string, column string) (string, error) {
+	numberPrefix := prefix + BusinessNow().Format("20060102")
+
+	next, err := nextDailySequence(tx, "returns."+column, numberPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%04d", numberPrefix, next), nil
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GenerateWaveCode generates a pick wave code with format: WV + YYYYMMDD + 3-digit auto increment
+// Example: WV20251008001, WV20251008002, etc.
+// It must be called inside the transaction that saves the code.
+func GenerateWaveCode(tx *gorm.DB) (string, error) {
+	codePrefix := "WV" + BusinessNow().Format("20060102")
+
+	next, err := nextDailySequence(tx, "pick_waves.code", codePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%03d", codePrefix, next), nil
+}
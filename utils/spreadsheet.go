@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSpreadsheetSize is the largest file accepted for import, well above a month of orders
+const maxSpreadsheetSize = 20 << 20
+
+// maxXLSXPartSize is the largest uncompressed part of a workbook that is read, so a small zip bomb cannot exhaust
+// the memory
+const maxXLSXPartSize = 100 << 20
+
+// ReadSpreadsheet reads all rows of an uploaded .csv or .xlsx file. For workbooks only the first sheet is read.
+// Rows are returned as they appear in the file, including the header row. Rows missing from a workbook are returned
+// empty, so rows[i] is always row i+1 of the sheet.
+func ReadSpreadsheet(fileHeader *multipart.FileHeader) ([][]string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSpreadsheetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSpreadsheetSize {
+		return nil, fmt.Errorf("file is larger than %d MB", maxSpreadsheetSize>>20)
+	}
+
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		return readCSVRows(data)
+	case ".xlsx":
+		return readXLSXRows(data)
+	default:
+		return nil, fmt.Errorf("unsupported file type '%s', use .csv or .xlsx", filepath.Ext(fileHeader.Filename))
+	}
+}
+
+// readCSVRows parses CSV data, accepting rows of different lengths and a UTF-8 byte order mark
+func readCSVRows(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return reader.ReadAll()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String joins the plain text and the formatted runs of a shared or inline string
+func (rt xlsxRichText) String() string {
+	var b strings.Builder
+	b.WriteString(rt.Text)
+	for _, run := range rt.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXRows parses the first sheet of an XLSX workbook into rows of cell text
+func readXLSXRows(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	decode := func(name string, v interface{}) error {
+		file, exists := files[name]
+		if !exists {
+			return fmt.Errorf("invalid xlsx file: %s not found", name)
+		}
+		if file.UncompressedSize64 > maxXLSXPartSize {
+			return fmt.Errorf("invalid xlsx file: %s is larger than %d MB uncompressed", name, maxXLSXPartSize>>20)
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		// The declared size is not trusted, the reader stops at the cap either way
+		return xml.NewDecoder(io.LimitReader(reader, maxXLSXPartSize)).Decode(v)
+	}
+
+	// Resolve the first sheet through the workbook relationships
+	sheetPath := "xl/worksheets/sheet1.xml"
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	if decode("xl/workbook.xml", &workbook) == nil && decode("xl/_rels/workbook.xml.rels", &relationships) == nil && len(workbook.Sheets) > 0 {
+		for _, rel := range relationships.Relationships {
+			if rel.ID != workbook.Sheets[0].RelID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPath = path.Join("xl", rel.Target)
+			}
+		}
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, exists := files["xl/sharedStrings.xml"]; exists {
+		if err := decode("xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet xlsxWorksheet
+	if err := decode(sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, sheetRow := range sheet.Rows {
+		// Keep rows at their row number, so the rows of sparse sheets are reported with the right number
+		if sheetRow.Ref != "" {
+			number, err := strconv.Atoi(sheetRow.Ref)
+			if err != nil || number < 1 || number > xlsxMaxRows {
+				return nil, fmt.Errorf("invalid xlsx file: invalid row number '%s'", sheetRow.Ref)
+			}
+			if number <= len(rows) {
+				return nil, fmt.Errorf("invalid xlsx file: row %d is out of order", number)
+			}
+			for len(rows) < number-1 {
+				rows = append(rows, nil)
+			}
+		}
+
+		var row []string
+		for i, cell := range sheetRow.Cells {
+			column := i
+			if cell.Ref != "" {
+				if column, err = xlsxColumnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err == nil && index >= 0 && index < len(sharedStrings.Items) {
+					row[column] = sharedStrings.Items[index].String()
+				}
+			case "inlineStr":
+				row[column] = cell.Inline.String()
+			case "", "n":
+				row[column] = formatXLSXNumber(cell.Value)
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Limits of an Excel sheet, which also keep malformed references from allocating huge rows
+const (
+	xlsxMaxRows    = 1048576
+	xlsxMaxColumns = 16384
+)
+
+// xlsxColumnIndex converts the column letters of a cell reference (e.g. "AB12") to a zero-based index
+func xlsxColumnIndex(ref string) (int, error) {
+	letters := strings.TrimRight(strings.ToUpper(ref), "0123456789")
+	if letters == "" || len(letters) == len(ref) {
+		return 0, fmt.Errorf("invalid xlsx file: invalid cell reference '%s'", ref)
+	}
+
+	index := 0
+	for _, r := range letters {
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("invalid xlsx file: invalid cell reference '%s'", ref)
+		}
+		index = index*26 + int(r-'A'+1)
+		if index > xlsxMaxColumns {
+			return 0, fmt.Errorf("invalid xlsx file: cell reference '%s' is beyond the last column", ref)
+		}
+	}
+	return index - 1, nil
+}
+
+// formatXLSXNumber writes numbers stored in scientific notation (e.g. long barcodes) as plain digits
+func formatXLSXNumber(value string) string {
+	if !strings.ContainsAny(value, "eE") {
+		return value
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// spreadsheetTimeLayouts are the date time formats accepted in imported files, day before month
+var spreadsheetTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02-01-2006 15:04:05",
+	"02-01-2006 15:04",
+	"2006-01-02",
+	"02/01/2006",
+	"02-01-2006",
+}
+
+// ParseSpreadsheetTime parses a date time cell of an imported file, in the business timezone unless the cell has one.
+// Besides the common text formats it accepts Excel date serial numbers, which is how unformatted xlsx date cells are
+// stored.
+func ParseSpreadsheetTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range spreadsheetTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, BusinessLocation()); err == nil {
+			return t, nil
+		}
+	}
+
+	// Excel counts days since 1899-12-30, with the time of day as fraction. The serial is a wall clock time,
+	// taken in the business timezone.
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 && serial < 2958466 {
+		excelEpoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		t := excelEpoch.Add(time.Duration(serial * 24 * float64(time.Hour))).Round(time.Second)
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, BusinessLocation()), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date time '%s'", value)
+}
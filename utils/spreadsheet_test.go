@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSpreadsheetTime(t *testing.T) {
+	cal, err := NewCalendar("Asia/Jakarta", "mon,tue,wed,thu,fri", "08:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	previous := BusinessCalendar()
+	SetBusinessCalendar(cal)
+	t.Cleanup(func() { SetBusinessCalendar(previous) })
+
+	jakarta := cal.Location
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-08-12 15:04:05", time.Date(2026, 8, 12, 15, 4, 5, 0, jakarta)},
+		{" 2026-08-12 15:04 ", time.Date(2026, 8, 12, 15, 4, 0, 0, jakarta)},
+		{"2026/08/12 15:04", time.Date(2026, 8, 12, 15, 4, 0, 0, jakarta)},
+		// Day before month
+		{"03/08/2026 09:30", time.Date(2026, 8, 3, 9, 30, 0, 0, jakarta)},
+		{"03-08-2026", time.Date(2026, 8, 3, 0, 0, 0, 0, jakarta)},
+		// A timezone in the cell wins over the business timezone
+		{"2026-08-12T08:00:00Z", time.Date(2026, 8, 12, 15, 0, 0, 0, jakarta)},
+		// Excel serials are wall clock times in the business timezone
+		{"46246", time.Date(2026, 8, 12, 0, 0, 0, 0, jakarta)},
+		{"46246.625", time.Date(2026, 8, 12, 15, 0, 0, 0, jakarta)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSpreadsheetTime(tt.value)
+		if err != nil {
+			t.Errorf("'%s': unexpected error: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("'%s': got %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "besok", "12/31/2026", "-1", "99999999"} {
+		if _, err := ParseSpreadsheetTime(value); err == nil {
+			t.Errorf("'%s': expected an error", value)
+		}
+	}
+}
+
+// buildXLSX zips the given parts into a workbook
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const (
+	testXLSXWorkbook = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Orders" sheetId="1" r:id="rId2"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets></workbook>`
+	testXLSXRelationships = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/orders.xml"/></Relationships>`
+	testXLSXSharedStrings = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Order ID</t></si><si><t>SKU</t></si><si><r><t>Kaos </t></r><r><t>Polos</t></r></si></sst>`
+)
+
+func testXLSXSheet(rows string) string {
+	return `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`
+}
+
+func TestReadXLSXRows(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":            testXLSXWorkbook,
+		"xl/_rels/workbook.xml.rels": testXLSXRelationships,
+		"xl/sharedStrings.xml":       testXLSXSharedStrings,
+		"xl/worksheets/sheet1.xml":   testXLSXSheet(`<row r="1"><c r="A1" t="inlineStr"><is><t>wrong sheet</t></is></c></row>`),
+		"xl/worksheets/orders.xml": testXLSXSheet(`
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c></row>
+<row r="3"><c r="A3" t="inlineStr"><is><t>INV-001</t></is></c><c r="B3"><v>8.99123456789E+11</v></c><c r="C3" t="b"><v>1</v></c></row>
+<row><c t="inlineStr"><is><t>INV-002</t></is></c><c><v>42</v></c></row>`),
+	})
+
+	rows, err := readXLSXRows(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{
+		{"Order ID", "SKU", "", "Kaos Polos"},
+		nil,
+		{"INV-001", "899123456789", "1"},
+		{"INV-002", "42"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %q", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") || len(rows[i]) != len(want[i]) {
+			t.Errorf("row %d: got %q, want %q", i+1, rows[i], want[i])
+		}
+	}
+}
+
+func TestReadXLSXRowsFallsBackToFirstSheetPath(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet1.xml": testXLSXSheet(`<row r="1"><c r="B1" t="inlineStr"><is><t>SKU</t></is></c></row>`),
+	})
+
+	rows, err := readXLSXRows(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || len(rows[0]) != 2 || rows[0][1] != "SKU" {
+		t.Errorf("got %q", rows)
+	}
+}
+
+func TestReadXLSXRowsRejectsMalformedSheets(t *testing.T) {
+	tests := map[string]string{
+		"out of order rows":   `<row r="2"><c r="A2"><v>1</v></c></row><row r="1"><c r="A1"><v>1</v></c></row>`,
+		"row beyond the last": `<row r="1048577"><c r="A1"><v>1</v></c></row>`,
+		"invalid row number":  `<row r="x"><c r="A1"><v>1</v></c></row>`,
+		"invalid cell":        `<row r="1"><c r="12"><v>1</v></c></row>`,
+		"column beyond last":  `<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+	}
+
+	for name, rows := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": testXLSXSheet(rows)})
+			if _, err := readXLSXRows(data); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	if _, err := readXLSXRows([]byte("not a zip")); err == nil {
+		t.Error("expected an error for a file that is not a workbook")
+	}
+	if _, err := readXLSXRows(buildXLSX(t, map[string]string{"xl/workbook.xml": testXLSXWorkbook})); err == nil {
+		t.Error("expected an error for a workbook without sheets")
+	}
+}
+
+func TestReadXLSXRowsRejectsOversizedParts(t *testing.T) {
+	// A sheet that inflates past the cap compresses to a few hundred kilobytes
+	padding := strings.Repeat(" ", maxXLSXPartSize+1)
+	data := buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": testXLSXSheet(padding)})
+	if len(data) > maxSpreadsheetSize {
+		t.Fatalf("test workbook is %d bytes, above the upload limit", len(data))
+	}
+
+	_, err := readXLSXRows(data)
+	if err == nil || !strings.Contains(err.Error(), "uncompressed") {
+		t.Errorf("expected an uncompressed size error, got: %v", err)
+	}
+}
+
+func TestXLSXColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "b7": 1, "Z3": 25, "AA10": 26, "AB12": 27, "XFD1": 16383}
+	for ref, want := range tests {
+		got, err := xlsxColumnIndex(ref)
+		if err != nil || got != want {
+			t.Errorf("'%s': got %d (%v), want %d", ref, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of the raw request body, optionally prefixed with "sha256="
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+// GenerateWebhookSecret generates a random shared secret for signing webhook payloads
+func GenerateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of the payload with the shared secret
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the signature header value against the payload in constant time
+func VerifyWebhookSignature(secret string, payload []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	received, err := hex.DecodeString(signature)
+	if err != nil || len(received) == 0 {
+		return false
+	}
+
+	expected, _ := hex.DecodeString(SignWebhookPayload(secret, payload))
+	return hmac.Equal(received, expected)
+}
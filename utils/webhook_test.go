@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Known HMAC-SHA256 test vector
+	got := SignWebhookPayload("key", []byte("The quick brown fox jumps over the lazy dog"))
+	want := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := "shared-secret"
+	payload := []byte(`{"order_id":"INV-001","status":"READY_TO_SHIP"}`)
+	signature := SignWebhookPayload(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: secret, payload: payload, signature: signature, want: true},
+		{name: "sha256 prefix", secret: secret, payload: payload, signature: "sha256=" + signature, want: true},
+		{name: "surrounding spaces", secret: secret, payload: payload, signature: "  sha256=" + signature + " ", want: true},
+		{name: "uppercase hex", secret: secret, payload: payload, signature: strings.ToUpper(signature), want: true},
+		{name: "wrong secret", secret: "other-secret", payload: payload, signature: signature, want: false},
+		{name: "tampered payload", secret: secret, payload: []byte(`{"order_id":"INV-002","status":"READY_TO_SHIP"}`), signature: signature, want: false},
+		{name: "truncated signature", secret: secret, payload: payload, signature: signature[:32], want: false},
+		{name: "not hex", secret: secret, payload: payload, signature: "not-a-signature", want: false},
+		{name: "empty", secret: secret, payload: payload, signature: "", want: false},
+		{name: "prefix only", secret: secret, payload: payload, signature: "sha256=", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyWebhookSignature(tt.secret, tt.payload, tt.signature); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateWebhookSecret(t *testing.T) {
+	first, err := GenerateWebhookSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := GenerateWebhookSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(first) != 64 {
+		t.Errorf("expected a 64 character hex secret, got %d characters", len(first))
+	}
+	if first == second {
+		t.Error("expected different secrets")
+	}
+}